@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/verse91/fsgo-dev-kit/internal/docs"
+	"github.com/verse91/fsgo-dev-kit/internal/generator"
+	"github.com/verse91/fsgo-dev-kit/internal/prompt"
+	"github.com/verse91/fsgo-dev-kit/pkg/utils"
+	"golang.org/x/term"
+)
+
+// defaultDocsWidth is the wrap width used when the terminal size is unknown
+const defaultDocsWidth = 80
+
+var (
+	// docsRaw prints the markdown source instead of rendering it
+	docsRaw bool
+	// docsNoPager writes straight to stdout even on a terminal
+	docsNoPager bool
+)
+
+// docsCmd renders the embedded framework documentation
+var docsCmd = &cobra.Command{
+	Use:   "docs [topic]",
+	Short: "Show documentation for a framework or the generated project layout",
+	Long: `Show the documentation fsgo writes into the docs/ folder of generated
+projects: which files each framework creates, how routing, middleware and
+environment variables work, and the common commands.
+
+Topics are named after the frameworks, e.g. fiber, gin, echo, next, react and
+svelte, plus "project" for the shared layout and Makefile. Without a topic the
+available topics are listed.
+
+On a terminal the output is shown in $PAGER (less by default); use --no-pager
+to print it directly and --raw to print the markdown source.
+
+Examples:
+  fsgo docs fiber
+  fsgo docs next --no-pager
+  fsgo docs project --raw > PROJECT.md`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: docs.Topics(),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runDocs(args); err != nil {
+			exitWithError("Error showing docs", err)
+		}
+	},
+}
+
+func init() {
+	docsCmd.Flags().BoolVar(&docsRaw, "raw", false, "print the markdown source instead of rendering it")
+	docsCmd.Flags().BoolVar(&docsNoPager, "no-pager", false, "print directly instead of using a pager")
+
+	rootCmd.AddCommand(docsCmd)
+}
+
+// runDocs prints the topic list or renders the requested topic
+func runDocs(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Available topics:")
+		for _, topic := range docs.Topics() {
+			fmt.Printf("  %s\n", topic)
+		}
+		fmt.Println("\nRun 'fsgo docs <topic>' to read one.")
+		return nil
+	}
+
+	topic, err := docs.Lookup(args[0])
+	if err != nil {
+		return err
+	}
+	output, err := generator.NewGeneratorRegistry().Doc(context.Background(), topic)
+	if err != nil {
+		return err
+	}
+
+	if !docsRaw {
+		output, err = docs.Render([]byte(output), docsWidth(), plainMode || prompt.IsPlainTerminal())
+		if err != nil {
+			return err
+		}
+	}
+
+	if docsNoPager || !utils.IsTerminal(os.Stdout) {
+		_, err = io.WriteString(os.Stdout, output)
+		return err
+	}
+	return page(output)
+}
+
+// docsWidth returns the wrap width for rendered docs
+func docsWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return defaultDocsWidth
+	}
+	return min(width, 120)
+}
+
+// page shows text in $PAGER, falling back to stdout when the pager can't run
+func page(text string) error {
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less", "-R"}
+	}
+
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if os.Getenv("LESS") == "" {
+		// Quit at once when the text fits on screen and keep colors
+		cmd.Env = append(os.Environ(), "LESS=FRX")
+	}
+
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return err
+		}
+		_, err = io.WriteString(os.Stdout, text)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/verse91/fsgo-dev-kit/internal/generator"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+// listJSON prints the descriptors as JSON instead of text
+var listJSON bool
+
+// listCmd describes the registered generators
+var listCmd = &cobra.Command{
+	Use:   "list [backends|frontends]",
+	Short: "List the available backend and frontend frameworks",
+	Long: `List the frameworks fsgo can generate, with a description, homepage, the
+tools that must be installed, supported options and the files each one creates.
+
+Use --json for machine-readable output, e.g. to generate documentation.
+
+Examples:
+  fsgo list
+  fsgo list frontends
+  fsgo list backends --json`,
+	ValidArgs: []string{"backends", "frontends"},
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	Run: func(cmd *cobra.Command, args []string) {
+		kind := ""
+		if len(args) > 0 {
+			kind = strings.TrimSuffix(args[0], "s")
+		}
+		if err := runList(os.Stdout, generator.NewGeneratorRegistry(), kind, listJSON); err != nil {
+			exitWithError("Error listing frameworks", err)
+		}
+	},
+}
+
+func init() {
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "print the framework descriptors as JSON")
+
+	rootCmd.AddCommand(listCmd)
+}
+
+// runList writes the backend and/or frontend descriptors to w. An empty kind
+// lists both.
+func runList(w io.Writer, registry *generator.GeneratorRegistry, kind string, asJSON bool) error {
+	listing := make(map[string][]types.GeneratorDescriptor)
+	if kind == "" || kind == "backend" {
+		listing["backends"] = registry.DescribeBackends()
+	}
+	if kind == "" || kind == "frontend" {
+		listing["frontends"] = registry.DescribeFrontends()
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(listing)
+	}
+
+	for i, section := range []struct{ key, title string }{{"backends", "Backend frameworks"}, {"frontends", "Frontend frameworks"}} {
+		descriptors, ok := listing[section.key]
+		if !ok {
+			continue
+		}
+		if i > 0 && len(listing) > 1 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s:\n", section.title)
+		for _, d := range descriptors {
+			printDescriptor(w, d)
+		}
+	}
+	return nil
+}
+
+// printDescriptor writes a single descriptor as indented text
+func printDescriptor(w io.Writer, d types.GeneratorDescriptor) {
+	fmt.Fprintf(w, "\n  %s (%s)\n", d.DisplayName, d.Name)
+	fmt.Fprintf(w, "    %s\n", d.Description)
+	fmt.Fprintf(w, "    %-18s%s\n", "Homepage:", d.Homepage)
+	fmt.Fprintf(w, "    %-18s%s\n", "Required tools:", orNone(d.RequiredTools))
+	if d.Options != nil {
+		fmt.Fprintf(w, "    %-18s%s\n", "Options:", orNone(d.Options))
+	}
+	if len(d.PackageManagers) > 0 {
+		fmt.Fprintf(w, "    %-18s%s\n", "Package managers:", orNone(d.PackageManagers))
+	}
+	if len(d.Dependencies) > 0 {
+		fmt.Fprintf(w, "    %-18s%s\n", "Dependencies:", orNone(d.Dependencies))
+	}
+	fmt.Fprintf(w, "    %s\n", "Layout:")
+	for _, path := range d.Layout {
+		fmt.Fprintf(w, "      %s\n", path)
+	}
+}
+
+// orNone joins values with commas, or returns "none" when there are none
+func orNone[T ~string](values []T) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return joinFrameworks(values)
+}
+
+// frameworkCompletions returns shell completions for the descriptors' names,
+// annotated with their display name and description
+func frameworkCompletions(descriptors []types.GeneratorDescriptor) cobra.CompletionFunc {
+	completions := make([]string, len(descriptors))
+	for i, d := range descriptors {
+		completions[i] = fmt.Sprintf("%s\t%s: %s", d.Name, d.DisplayName, d.Description)
+	}
+	return cobra.FixedCompletions(completions, cobra.ShellCompDirectiveNoFileComp)
+}
+
+// registerProjectCompletions completes the framework flags bound by
+// projectOptions.bind from the generator registry
+func registerProjectCompletions(cmd *cobra.Command) {
+	registry := generator.NewGeneratorRegistry()
+
+	var packageManagers []string
+	for _, pm := range types.GetPackageManagers() {
+		packageManagers = append(packageManagers, string(pm))
+	}
+
+	completions := map[string]cobra.CompletionFunc{
+		"type":            cobra.FixedCompletions([]string{"web\tFull-stack with frontend + backend", "api\tBackend only"}, cobra.ShellCompDirectiveNoFileComp),
+		"backend":         frameworkCompletions(registry.DescribeBackends()),
+		"frontend":        frameworkCompletions(registry.DescribeFrontends()),
+		"package-manager": cobra.FixedCompletions(packageManagers, cobra.ShellCompDirectiveNoFileComp),
+	}
+	for flag, completion := range completions {
+		_ = cmd.RegisterFlagCompletionFunc(flag, completion)
+	}
+}
@@ -0,0 +1,392 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/verse91/fsgo-dev-kit/internal/generator"
+	"github.com/verse91/fsgo-dev-kit/internal/prompt"
+	"github.com/verse91/fsgo-dev-kit/internal/spec"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
+	"github.com/verse91/fsgo-dev-kit/internal/tui"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/internal/userconfig"
+	"github.com/verse91/fsgo-dev-kit/internal/validate"
+	"github.com/verse91/fsgo-dev-kit/pkg/utils"
+)
+
+// projectOptions holds the flags that describe a project
+type projectOptions struct {
+	name           string
+	path           string
+	projectType    string
+	backend        string
+	modulePath     string
+	frontend       string
+	typeScript     bool
+	tailwindCSS    bool
+	eslint         bool
+	packageManager string
+}
+
+// fieldFlags maps each configuration field to the flag that supplies it
+var fieldFlags = map[types.ConfigField]string{
+	types.FieldPath:           "path",
+	types.FieldType:           "type",
+	types.FieldBackend:        "backend",
+	types.FieldModulePath:     "module-path",
+	types.FieldFrontend:       "frontend",
+	types.FieldTypeScript:     "typescript",
+	types.FieldTailwindCSS:    "tailwind",
+	types.FieldESLint:         "eslint",
+	types.FieldPackageManager: "package-manager",
+}
+
+// configSources names the preset and spec file project options are read from
+type configSources struct {
+	preset   string
+	specFile string
+}
+
+var (
+	newOptions projectOptions
+	newSources configSources
+	assumeYes  bool
+	force      bool
+)
+
+// newCmd creates a project from flags, prompting only for missing options
+var newCmd = &cobra.Command{
+	Use:   "new [path]",
+	Short: "Create a new project from command line flags",
+	Long: `Create a new project without going through the full interactive wizard.
+
+Every option can be supplied with a flag. Options that are not supplied are
+prompted for when stdin is a terminal; otherwise the command fails and lists
+the missing flags. Use --yes to accept the defaults for every missing option.
+
+A saved preset (--preset) and a YAML or JSON spec file (--file) can supply
+the options instead. Values from the spec file override the preset, and flags
+given on the command line override both.
+
+Examples:
+  fsgo new my-app --type web --backend fiber --frontend next
+  fsgo new my-api --type api --backend gin
+  fsgo new my-app --yes
+  fsgo new -f fsgo.yaml
+  fsgo new my-app --preset team
+  fsgo new my-app --yes --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runNew(cmd, args)
+	},
+}
+
+func init() {
+	newOptions.bind(newCmd.Flags(), true)
+	registerProjectCompletions(newCmd)
+	newCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "use defaults for every option that is not supplied and skip all prompts")
+	newCmd.Flags().BoolVar(&force, "force", false, "generate into a directory even if it already contains files")
+	newCmd.Flags().StringVarP(&newSources.specFile, "file", "f", "", "read project options from a YAML or JSON spec file")
+	newCmd.Flags().StringVar(&newSources.preset, "preset", "", "start from a preset saved with 'fsgo preset save'")
+	newCmd.Flags().StringVar(&saveSpecPath, "save-spec", "", "write the resolved options to a spec file that can be replayed with --file")
+	bindGenerateFlags(newCmd)
+
+	rootCmd.AddCommand(newCmd)
+}
+
+// bind registers the project flags on the given flag set. The name, path and
+// module path flags are left out when withPath is false.
+func (o *projectOptions) bind(flags *pflag.FlagSet, withPath bool) {
+	if withPath {
+		flags.StringVar(&o.name, "name", "", "project name (defaults to the directory name)")
+		flags.StringVar(&o.path, "path", "", "directory to create the project in, '.' for the current directory")
+		flags.StringVar(&o.modulePath, "module-path", "", "Go module path of the backend (defaults to one derived from the git remote or the project name)")
+	}
+	flags.StringVarP(&o.projectType, "type", "t", "", "project type: web or api")
+	flags.StringVarP(&o.backend, "backend", "b", "", "backend framework (see 'fsgo list backends')")
+	flags.StringVar(&o.frontend, "frontend", "", "frontend framework (see 'fsgo list frontends')")
+	flags.BoolVar(&o.typeScript, "typescript", true, "use TypeScript in the frontend")
+	flags.BoolVar(&o.tailwindCSS, "tailwind", true, "use Tailwind CSS in the frontend")
+	flags.BoolVar(&o.eslint, "eslint", true, "use ESLint in the frontend")
+	flags.StringVar(&o.packageManager, "package-manager", "", "frontend package manager, e.g. bun, npm, pnpm or yarn (defaults to the frontend's preferred one)")
+}
+
+// apply copies every flag that was explicitly set into config and records it in set
+func (o *projectOptions) apply(registry *generator.GeneratorRegistry, flags *pflag.FlagSet, args []string, config *types.ProjectConfig, set types.FieldSet) error {
+	path := o.path
+	if len(args) > 0 {
+		if flags.Changed("path") && path != args[0] {
+			return fmt.Errorf("path given both as argument (%s) and --path (%s)", args[0], path)
+		}
+		path = args[0]
+	}
+	if path == "" && o.name != "" {
+		path = o.name
+	}
+	if path != "" {
+		name, err := utils.ProjectNameFromPath(path)
+		if err != nil {
+			return err
+		}
+		config.Path = path
+		config.Name = name
+		set[types.FieldPath] = true
+	}
+	if o.name != "" {
+		config.Name = o.name
+	}
+
+	if flags.Changed("type") {
+		projectType, err := types.ParseProjectType(o.projectType)
+		if err != nil {
+			return err
+		}
+		config.Type = projectType
+		set[types.FieldType] = true
+	}
+
+	if flags.Changed("backend") {
+		backend, err := registry.ParseBackendFramework(o.backend)
+		if err != nil {
+			return err
+		}
+		if _, exists := registry.GetBackendGenerator(backend); !exists {
+			return fmt.Errorf("%w (available: %s)", &types.UnknownFrameworkError{Kind: "backend", Name: string(backend)}, joinFrameworks(registry.GetAvailableBackendFrameworks()))
+		}
+		config.BackendFramework = backend
+		set[types.FieldBackend] = true
+	}
+
+	if flags.Changed("module-path") {
+		if err := validate.ModulePath(o.modulePath); err != nil {
+			return err
+		}
+		config.ModulePath = o.modulePath
+		set[types.FieldModulePath] = true
+	}
+
+	frontendFlags := map[string]types.ConfigField{
+		"frontend":        types.FieldFrontend,
+		"typescript":      types.FieldTypeScript,
+		"tailwind":        types.FieldTailwindCSS,
+		"eslint":          types.FieldESLint,
+		"package-manager": types.FieldPackageManager,
+	}
+	for name, field := range frontendFlags {
+		if !flags.Changed(name) {
+			continue
+		}
+		if config.Frontend == nil {
+			config.Frontend = &types.FrontendConfig{}
+		}
+		set[field] = true
+	}
+	if config.Frontend == nil {
+		return nil
+	}
+
+	if flags.Changed("frontend") {
+		frontend, err := registry.ParseFrontendFramework(o.frontend)
+		if err != nil {
+			return err
+		}
+		config.Frontend.Framework = frontend
+	}
+	if flags.Changed("typescript") {
+		config.Frontend.TypeScript = o.typeScript
+	}
+	if flags.Changed("tailwind") {
+		config.Frontend.TailwindCSS = o.tailwindCSS
+	}
+	if flags.Changed("eslint") {
+		config.Frontend.ESLint = o.eslint
+	}
+	if flags.Changed("package-manager") {
+		pm, err := types.ParsePackageManager(o.packageManager)
+		if err != nil {
+			return err
+		}
+		config.Frontend.PackageManager = pm
+	}
+
+	return nil
+}
+
+// runNew builds the project configuration from flags and generates the project
+func runNew(cmd *cobra.Command, args []string) {
+	if err := checkGenerateFlags(); err != nil {
+		exitWithError("Error generating project", err)
+	}
+
+	registry, library, err := newRegistry()
+	if err != nil {
+		exitWithError("Error generating project", err)
+	}
+	prompter, defaults, err := newPrompter(registry, library, true)
+	if err != nil {
+		exitWithError("Error generating project", err)
+	}
+
+	prompter.SetAllowNonEmpty(allowNonEmpty())
+
+	config, set, err := loadConfig(registry, newSources, &newOptions, cmd.Flags(), args)
+	interactive := false
+	if err == nil {
+		interactive = !assumeYes && len(set.Missing(config)) > 0
+		err = completeConfig(registry, config, set, defaults, prompter, assumeYes)
+	}
+	if err == nil {
+		err = validateTarget(config)
+	}
+	if err != nil {
+		exitWithError("Error generating project", err)
+	}
+
+	generateProject(prompter, config, interactive)
+}
+
+// newPrompter creates the interactive front end, starting from the user's
+// global defaults: plain prompts without a terminal or with --plain, the
+// classic prompts with --classic and the full-screen wizard otherwise, whose
+// preview uses the template sets of library. With review set the wizard ends
+// in a review step before generating.
+func newPrompter(registry *generator.GeneratorRegistry, library *templates.Library, review bool) (projectWizard, *types.ProjectConfig, error) {
+	defaults, err := userconfig.LoadDefaults(registry)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading defaults: %w", err)
+	}
+
+	switch {
+	case plainMode || prompt.IsPlainTerminal():
+		prompter := prompt.NewPlainProjectPrompt(registry, os.Stdin, os.Stdout)
+		prompter.SetDefaults(defaults)
+		return prompter, defaults, nil
+	case classicMode:
+		prompter := prompt.NewProjectPrompt(registry)
+		prompter.SetDefaults(defaults)
+		return prompter, defaults, nil
+	}
+
+	wizard := tui.NewWizard(registry)
+	wizard.SetTemplates(library)
+	wizard.SetDefaults(defaults)
+	wizard.SetReview(review)
+	return wizard, defaults, nil
+}
+
+// loadConfig merges a preset, a spec file and flags into a partial
+// configuration, with later sources taking precedence
+func loadConfig(registry *generator.GeneratorRegistry, sources configSources, opts *projectOptions, flags *pflag.FlagSet, args []string) (*types.ProjectConfig, types.FieldSet, error) {
+	config := &types.ProjectConfig{}
+	set := types.FieldSet{}
+
+	if sources.preset != "" {
+		preset, presetSet, err := userconfig.LoadPreset(sources.preset, registry)
+		if err != nil {
+			return nil, nil, err
+		}
+		set.Merge(config, preset, presetSet)
+	}
+
+	if sources.specFile != "" {
+		specConfig, specSet, err := spec.Load(sources.specFile, registry)
+		if err != nil {
+			return nil, nil, err
+		}
+		set.Merge(config, specConfig, specSet)
+	}
+
+	if err := opts.apply(registry, flags, args, config, set); err != nil {
+		return nil, nil, err
+	}
+
+	return config, set, nil
+}
+
+// validateTarget applies the wizard's name and path checks to a configuration
+// that was built without prompting
+func validateTarget(config *types.ProjectConfig) error {
+	if err := validate.Project(config.Name, config.Path); err != nil {
+		return err
+	}
+	if !allowNonEmpty() {
+		if err := validate.TargetDir(config.Path); err != nil {
+			return fmt.Errorf("%w (pass --force or --on-conflict to generate into it anyway)", err)
+		}
+	}
+	return nil
+}
+
+// allowNonEmpty reports whether the project may be generated into a directory
+// that already contains files: with --force, with --on-conflict saying what to
+// do with them, or on a dry run, which writes nothing
+func allowNonEmpty() bool {
+	return force || onConflict != "" || dryRun
+}
+
+// completeConfig fills every field missing from config, either from defaults
+// when prompting is disabled or by asking the user when stdin is a terminal
+// and events are not requested
+func completeConfig(registry *generator.GeneratorRegistry, config *types.ProjectConfig, set types.FieldSet, defaults *types.ProjectConfig, prompter projectWizard, yes bool) error {
+	// Options the chosen frontend can't use are never asked for
+	if err := restrictFrontend(registry, config, set); err != nil {
+		return err
+	}
+
+	missing := set.Missing(config)
+	if len(missing) == 0 || yes {
+		fallback := *defaults
+		fallback.Path = "."
+		name, err := utils.ProjectNameFromPath(fallback.Path)
+		if err != nil {
+			return err
+		}
+		fallback.Name = name
+		set.FillMissing(config, &fallback)
+		return restrictFrontend(registry, config, set)
+	}
+
+	if !canPrompt() {
+		flags := make([]string, len(missing))
+		for i, field := range missing {
+			flags[i] = "--" + fieldFlags[field]
+		}
+		reason := "stdin is not a terminal"
+		if jsonOutput() {
+			reason = "--output json never prompts"
+		}
+		return usageErrorf("%s and these options were not supplied: %s (pass them as flags or use --yes to accept the defaults)", reason, strings.Join(flags, ", "))
+	}
+
+	if err := prompter.CompleteProjectConfig(config, set.Prompted(config)); err != nil {
+		return fmt.Errorf("error getting project configuration: %w", err)
+	}
+
+	return restrictFrontend(registry, config, set)
+}
+
+// restrictFrontend applies the capabilities of the chosen frontend generator
+// to config, failing when an unsupported option was explicitly requested
+func restrictFrontend(registry *generator.GeneratorRegistry, config *types.ProjectConfig, set types.FieldSet) error {
+	if config.Frontend == nil || config.Frontend.Framework == "" {
+		return nil
+	}
+	capabilities, exists := registry.GetFrontendCapabilities(config.Frontend.Framework)
+	if !exists {
+		return fmt.Errorf("%w (available: %s)", &types.UnknownFrameworkError{Kind: "frontend", Name: string(config.Frontend.Framework)}, joinFrameworks(registry.GetAvailableFrontendFrameworks()))
+	}
+	return capabilities.Restrict(config.Frontend, set)
+}
+
+// joinFrameworks formats framework names for error messages
+func joinFrameworks[T ~string](frameworks []T) string {
+	names := make([]string, len(frameworks))
+	for i, framework := range frameworks {
+		names[i] = string(framework)
+	}
+	return strings.Join(names, ", ")
+}
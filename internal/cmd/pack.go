@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/verse91/fsgo-dev-kit/internal/generator"
+	"github.com/verse91/fsgo-dev-kit/internal/pack"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
+	"github.com/verse91/fsgo-dev-kit/internal/userconfig"
+)
+
+var (
+	// templateSource is the template pack given by --template
+	templateSource string
+	// loadedPack is the template pack of this run once it was fetched
+	loadedPack *pack.Pack
+)
+
+// templatePack returns the template pack given by --template, fetching it on
+// first use, or nil without --template. Cancelling ctx stops the fetch.
+func templatePack(ctx context.Context) (*pack.Pack, error) {
+	if templateSource == "" || loadedPack != nil {
+		return loadedPack, nil
+	}
+	source, err := pack.ParseSource(templateSource)
+	if err != nil {
+		return nil, err
+	}
+	cacheDir, err := userconfig.PacksPath()
+	if err != nil {
+		return nil, err
+	}
+	if loadedPack, err = pack.Open(ctx, source, cacheDir); err != nil {
+		return nil, err
+	}
+	return loadedPack, nil
+}
+
+// newRegistry returns the generators of this run, the built-in ones along
+// with those defined by the template pack, and the library they load their
+// template sets from. Ctrl-C stops fetching the pack.
+func newRegistry() (*generator.GeneratorRegistry, *templates.Library, error) {
+	ctx, stop := interruptContext()
+	defer stop()
+	return newGenerators(ctx)
+}
+
+// newGenerators returns the generators of this run and the library they load
+// their template sets from. The library looks in the override directory
+// first, then in the template pack and finally in the built-in templates.
+// Cancelling ctx stops fetching the pack.
+func newGenerators(ctx context.Context) (*generator.GeneratorRegistry, *templates.Library, error) {
+	var dirs []string
+	overrides, err := templateOverrideDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	if overrides != "" {
+		dirs = append(dirs, overrides)
+	}
+	p, err := templatePack(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if p != nil {
+		dirs = append(dirs, p.TemplatesPath())
+	}
+
+	registry := generator.NewGeneratorRegistry()
+	library := templates.NewLibrary(dirs...)
+	if p != nil {
+		if err := p.Register(registry, library); err != nil {
+			return nil, nil, err
+		}
+	}
+	return registry, library, nil
+}
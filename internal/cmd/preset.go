@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/verse91/fsgo-dev-kit/internal/generator"
+	"github.com/verse91/fsgo-dev-kit/internal/spec"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/internal/userconfig"
+)
+
+var (
+	presetOptions projectOptions
+	presetSources configSources
+)
+
+// presetCmd groups the commands that manage saved presets
+var presetCmd = &cobra.Command{
+	Use:   "preset",
+	Short: "Manage saved project presets",
+	Long: `Presets store a project configuration without its name and path so the same
+stack can be reused with 'fsgo new --preset <name>'.
+
+Presets are kept in the presets directory of the fsgo config directory
+($FSGO_CONFIG_DIR, or fsgo under the user config directory). A defaults.yaml
+file in the same format next to it changes the answers the wizard starts from.`,
+}
+
+// presetSaveCmd saves a new preset
+var presetSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save a preset from flags, a spec file or prompts",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		registry := generator.NewGeneratorRegistry()
+		prompter, defaults, err := newPrompter(registry, templates.Builtin(), false)
+		if err != nil {
+			exitWithError("Error saving preset", err)
+		}
+
+		config, set, err := loadConfig(registry, presetSources, &presetOptions, cmd.Flags(), nil)
+		if err == nil {
+			// Presets never carry a name or path
+			set[types.FieldPath] = true
+			err = completeConfig(registry, config, set, defaults, prompter, assumeYes)
+		}
+		if err == nil {
+			err = userconfig.SavePreset(args[0], config)
+		}
+		if err != nil {
+			exitWithError("Error saving preset", err)
+		}
+
+		fmt.Printf("✅ Preset %s saved\n", args[0])
+	},
+}
+
+// presetListCmd lists saved presets
+var presetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved presets",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		names, err := userconfig.ListPresets()
+		if err != nil {
+			exitWithError("Error listing presets", err)
+		}
+		if len(names) == 0 {
+			fmt.Println("No presets saved yet. Create one with 'fsgo preset save <name>'.")
+			return
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+	},
+}
+
+// presetShowCmd prints a saved preset
+var presetShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show the options stored in a preset",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		config, _, err := userconfig.LoadPreset(args[0], generator.NewGeneratorRegistry())
+		if err != nil {
+			exitWithError("Error loading preset", err)
+		}
+		data, err := spec.Marshal(config, false)
+		if err != nil {
+			exitWithError("Error loading preset", err)
+		}
+		fmt.Print(string(data))
+	},
+}
+
+// presetDeleteCmd removes a saved preset
+var presetDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a saved preset",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := userconfig.DeletePreset(args[0]); err != nil {
+			exitWithError("Error deleting preset", err)
+		}
+		fmt.Printf("🗑️  Preset %s deleted\n", args[0])
+	},
+}
+
+func init() {
+	flags := presetSaveCmd.Flags()
+	presetOptions.bind(flags, false)
+	registerProjectCompletions(presetSaveCmd)
+	flags.StringVarP(&presetSources.specFile, "file", "f", "", "read the preset options from a YAML or JSON spec file")
+	flags.StringVar(&presetSources.preset, "from", "", "start from an existing preset")
+	flags.BoolVarP(&assumeYes, "yes", "y", false, "use defaults for every option that is not supplied and skip all prompts")
+
+	presetCmd.AddCommand(presetSaveCmd, presetListCmd, presetShowCmd, presetDeleteCmd)
+	rootCmd.AddCommand(presetCmd)
+}
@@ -1,41 +1,121 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
+	"github.com/verse91/fsgo-dev-kit/internal/events"
 	"github.com/verse91/fsgo-dev-kit/internal/generator"
+	"github.com/verse91/fsgo-dev-kit/internal/pack"
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/progress"
+	"github.com/verse91/fsgo-dev-kit/internal/prompt"
+	"github.com/verse91/fsgo-dev-kit/internal/runner"
+	"github.com/verse91/fsgo-dev-kit/internal/spec"
+	"github.com/verse91/fsgo-dev-kit/internal/staging"
+	"github.com/verse91/fsgo-dev-kit/internal/tui"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/internal/validate"
+	"github.com/verse91/fsgo-dev-kit/pkg/fsys"
+	"github.com/verse91/fsgo-dev-kit/pkg/utils"
 )
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "fsgo",
 	Short: "Interactive generator for full-stack projects with multiple framework options",
-	Long: `fsgo is an interactive CLI tool that generates full-stack projects with your choice of:
-
-Backend Frameworks:
-  • Fiber (Go)
-  • Gin (Go) 
-  • Echo (Go)
-  • Chi (Go)
-
-Frontend Frameworks:
-  • Next.js
-  • React
-  • Svelte
-  • SvelteKit
-  • Vue
-  • Solid
+	Run: func(cmd *cobra.Command, args []string) {
+		runGenerator()
+	},
+}
+
+var (
+	// saveSpecPath is where the resolved project options are exported, if set
+	saveSpecPath string
+	// plainMode forces line-based prompts without box-drawing or ANSI output
+	plainMode bool
+	// classicMode uses the question-by-question prompts instead of the full-screen wizard
+	classicMode bool
+	// dryRun prints the generation plan instead of generating the project
+	dryRun bool
+	// planJSON prints the dry run plan as JSON
+	planJSON bool
+	// keepFailed keeps the staging directory of a failed generation
+	keepFailed bool
+	// onConflict resolves every file that already exists in the target, if set
+	onConflict string
+	// jobs limits how many generators run at once; 0 means no limit
+	jobs int
+	// verbose streams the output of external commands instead of showing
+	// progress
+	verbose bool
+	// outputFormat is text for people or json for a stream of events
+	outputFormat string
+	// strict turns failures setting up dependencies into errors
+	strict bool
+)
+
+// abortedMessage is printed when the user aborts before anything is generated
+const abortedMessage = "Aborted, no project files were written."
+
+// interruptedMessage is printed when generation is cancelled with Ctrl-C
+const interruptedMessage = "Interrupted, the generation was cancelled and rolled back."
+
+// projectWizard asks for the project configuration and reviews it before
+// generating. It is implemented by the full-screen wizard and by the classic
+// and plain prompts.
+type projectWizard interface {
+	GetProjectConfig() (*types.ProjectConfig, error)
+	CompleteProjectConfig(config *types.ProjectConfig, fields []types.ConfigField) error
+	Review(config *types.ProjectConfig) (bool, error)
+	ShowSummary(config *types.ProjectConfig)
+	SetAllowNonEmpty(allow bool)
+	ResolveConflict(conflict staging.Conflict) (staging.Resolution, error)
+}
+
+func init() {
+	rootCmd.Long = rootLong(generator.NewGeneratorRegistry())
+	rootCmd.PersistentFlags().BoolVar(&classicMode, "classic", false, "ask questions one by one instead of using the full-screen wizard")
+	rootCmd.PersistentFlags().BoolVar(&plainMode, "plain", false, "use plain, line-based prompts (enabled automatically without a terminal or when NO_COLOR is set)")
+	rootCmd.Flags().StringVar(&saveSpecPath, "save-spec", "", "write the answers to a spec file that can be replayed with 'fsgo new --file'")
+	bindGenerateFlags(rootCmd)
+}
+
+// rootLong builds the root help text from the registered generators
+func rootLong(registry *generator.GeneratorRegistry) string {
+	var b strings.Builder
+	b.WriteString("fsgo is an interactive CLI tool that generates full-stack projects with your choice of:\n")
+
+	sections := []struct {
+		title       string
+		descriptors []types.GeneratorDescriptor
+	}{
+		{"Backend Frameworks", registry.DescribeBackends()},
+		{"Frontend Frameworks", registry.DescribeFrontends()},
+	}
+	for _, section := range sections {
+		fmt.Fprintf(&b, "\n%s:\n", section.title)
+		for _, d := range section.descriptors {
+			fmt.Fprintf(&b, "  • %s - %s\n", d.DisplayName, d.Description)
+		}
+	}
 
+	b.WriteString(`
 Project Types:
   • Web (Full-stack with frontend + backend)
   • API (Backend only)
 
-Simply run 'fsgo' and follow the interactive prompts to configure your project.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		runGenerator()
-	},
+Simply run 'fsgo' and follow the interactive prompts to configure your project,
+or use 'fsgo new' with flags to create a project without prompts. Run
+'fsgo list' for details about each framework.`)
+	return b.String()
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -43,15 +123,447 @@ Simply run 'fsgo' and follow the interactive prompts to configure your project.`
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 }
 
 // runGenerator executes the project generation logic
 func runGenerator() {
+	if err := checkGenerateFlags(); err != nil {
+		exitWithError("Error generating project", err)
+	}
+	if jsonOutput() {
+		exitWithError("Error generating project", usageErrorf("--output json needs every option up front; use 'fsgo new' with flags, a spec file or --yes"))
+	}
+
+	registry, library, err := newRegistry()
+	if err != nil {
+		exitWithError("Error generating project", err)
+	}
+	prompter, _, err := newPrompter(registry, library, true)
+	if err != nil {
+		exitWithError("Error generating project", err)
+	}
+
+	config, err := prompter.GetProjectConfig()
+	if err != nil {
+		exitWithError("Error generating project", fmt.Errorf("error getting project configuration: %w", err))
+	}
+
+	generateProject(prompter, config, true)
+}
+
+// generateProject shows the configuration summary and generates the project.
+// With review set the summary ends in a menu that can edit the answers or
+// abort before anything is written.
+func generateProject(prompter projectWizard, config *types.ProjectConfig, review bool) {
+	if config.ModulePath == "" {
+		config.ModulePath = defaultModulePath(config)
+	}
+
+	if review {
+		proceed, err := prompter.Review(config)
+		if err != nil {
+			exitWithError("Error generating project", err)
+		}
+		if !proceed {
+			fmt.Println(abortedMessage)
+			return
+		}
+	} else if !planJSON && !jsonOutput() {
+		prompter.ShowSummary(config)
+	}
+
+	handler := newEventHandler()
+	handler.Handle(events.Event{Type: events.ConfigResolved, Config: spec.NewDocument(config)})
+
+	if saveSpecPath != "" {
+		if err := spec.Save(saveSpecPath, config); err != nil {
+			exitWithError("Error saving spec file", err)
+		}
+		handler.Handle(events.Event{Type: events.Notice, Message: fmt.Sprintf("Saved project spec to %s", saveSpecPath)})
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	projectGen, err := newProjectGenerator(ctx, handler)
+	if err != nil {
+		exitWithError("Error generating project", err)
+	}
+	if dryRun {
+		if err := printPlan(ctx, projectGen, config); err != nil {
+			exitWithError("Error planning project", err)
+		}
+		return
+	}
+
+	if err := generateStaged(ctx, prompter, projectGen, config, handler); err != nil {
+		exitWithError("Error generating project", err)
+	}
+}
+
+// newProjectGenerator returns the project generator of this run, with the
+// generators of the template pack and templates from the override directory
+// and the pack, and reports which of them are used
+func newProjectGenerator(ctx context.Context, handler events.Handler) (*generator.ProjectGenerator, error) {
+	registry, library, err := newGenerators(ctx)
+	if err != nil {
+		return nil, err
+	}
 	projectGen := generator.NewProjectGenerator()
-	if err := projectGen.Generate(); err != nil {
-		fmt.Printf("Error generating project: %v\n", err)
-		os.Exit(1)
+	projectGen.SetRegistry(registry)
+	projectGen.SetTemplates(library)
+
+	if !dryRun {
+		if overrides, _ := templateOverrideDir(); overrides != "" {
+			handler.Handle(events.Event{Type: events.Notice, Message: fmt.Sprintf("Using template overrides from %s", overrides)})
+		}
+		if p, _ := templatePack(ctx); p != nil {
+			handler.Handle(events.Event{Type: events.Notice, Message: fmt.Sprintf("Using template pack %s from %s", p.Name(), p.Source)})
+		}
+	}
+	return projectGen, nil
+}
+
+// defaultModulePath returns the module path of the server of config when none
+// was given: the one derived from the git remote if it is valid, and the one
+// derived from the project name otherwise
+func defaultModulePath(config *types.ProjectConfig) string {
+	modulePath := utils.DefaultModulePath(config.Path, config.Name)
+	if validate.ModulePath(modulePath) != nil {
+		return utils.ModulePathFromName(config.Name)
+	}
+	return modulePath
+}
+
+// interruptContext returns a context that is cancelled by Ctrl-C or SIGTERM.
+// The first signal restores the default handling, so a second Ctrl-C exits
+// immediately.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+// generateStaged generates the project in a staging directory and moves it
+// into place only once every step succeeded. When generation fails or is
+// cancelled nothing fsgo created is left behind, unless --keep-failed asks to
+// keep the staging directory. When moving into place fails the staging
+// directory is always kept, since it holds any files that could not be put
+// back. Progress is reported to handler, ending in a done event on success.
+func generateStaged(ctx context.Context, prompter projectWizard, projectGen *generator.ProjectGenerator, config *types.ProjectConfig, handler events.Handler) error {
+	p, err := projectGen.Plan(ctx, config)
+	if err != nil {
+		return err
+	}
+	if err := projectGen.CheckTools(config, p); err != nil {
+		return err
+	}
+	resolve, err := resolveConflicts(prompter, config, p)
+	if err != nil {
+		return err
+	}
+
+	area, err := staging.New(config.Path)
+	if err != nil {
+		return err
+	}
+	area.SetResolver(resolve)
+
+	logName, warnings, err := stage(ctx, p, area, handler)
+	if err != nil {
+		if discardErr := area.Discard(keepFailed); discardErr != nil {
+			return errors.Join(err, discardErr)
+		}
+		if keepFailed {
+			handler.Handle(events.Event{Type: events.Notice, Message: fmt.Sprintf("Kept the failed generation in %s", area.Dir())})
+		}
+		return err
+	}
+
+	// A failed commit keeps the staging directory whatever --keep-failed
+	// says: files it could not put back are still in its backup directory
+	if err := area.Commit(); err != nil {
+		handler.Handle(events.Event{Type: events.Notice, Message: fmt.Sprintf("Kept the generated project and any replaced files in %s", area.Dir())})
+		return err
 	}
-}
\ No newline at end of file
+
+	handler.Handle(events.Event{
+		Type:      events.Done,
+		Name:      config.Name,
+		Path:      config.Path,
+		Log:       filepath.Join(config.Path, filepath.FromSlash(runner.LogDir), logName),
+		NextSteps: generator.NextSteps(config),
+		Warnings:  warnings,
+	})
+	return nil
+}
+
+// newEventHandler returns where generation reports its progress: a stream of
+// JSON events with --output json, the output of every command with
+// --verbose, otherwise spinners, or one line per step without a terminal or
+// with --plain
+func newEventHandler() events.Handler {
+	switch {
+	case jsonOutput():
+		return events.NewJSON(os.Stdout)
+	case verbose:
+		return progress.New(os.Stdout, progress.Verbose)
+	case plainMode || prompt.IsPlainTerminal():
+		return progress.New(os.Stdout, progress.Plain)
+	default:
+		return progress.New(os.Stdout, progress.Animated)
+	}
+}
+
+// stage applies p to the staging directory of area, running at most --jobs
+// generators at once and reporting progress to handler. The output of external
+// commands is also recorded in a transcript below .fsgo/logs, which moves
+// into the project with everything else. stage returns its file name and the
+// warnings about optional commands that failed.
+func stage(ctx context.Context, p *plan.Plan, area *staging.Area, handler events.Handler) (string, []string, error) {
+	root, err := fsys.NewOS(area.Dir())
+	if err != nil {
+		return "", nil, err
+	}
+
+	transcript, err := runner.CreateTranscript(area.Dir())
+	if err != nil {
+		return "", nil, err
+	}
+	defer transcript.Close()
+
+	exec := runner.NewExec(os.Stdout)
+	exec.SetTranscript(transcript)
+	executor := &plan.Executor{Runner: exec, Events: handler, Jobs: jobs, Strict: strict}
+	err = executor.Apply(ctx, p, root)
+	return filepath.Base(transcript.Name()), executor.Warnings(), err
+}
+
+// resolveConflicts finds the files p would write over before anything is
+// generated and decides what to do with each one: as --on-conflict says, by
+// asking when stdin is a terminal, or by failing. The returned resolver
+// answers with those decisions on commit and resolves files created by
+// external commands the same way.
+func resolveConflicts(prompter projectWizard, config *types.ProjectConfig, p *plan.Plan) (staging.Resolver, error) {
+	conflicts, err := detectConflicts(config, p)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolve staging.Resolver
+	switch {
+	case onConflict != "":
+		resolution, err := staging.ParseResolution(onConflict)
+		if err != nil {
+			return nil, err
+		}
+		resolve = staging.Always(resolution)
+	case canPrompt():
+		resolve = prompter.ResolveConflict
+	default:
+		if len(conflicts) > 0 {
+			paths := make([]string, len(conflicts))
+			for i, conflict := range conflicts {
+				paths[i] = conflict.Path
+			}
+			return nil, fmt.Errorf("%w: %s (pass --on-conflict skip, overwrite or new to resolve them)", staging.ErrConflict, strings.Join(paths, ", "))
+		}
+		resolve = staging.Always(staging.ResolveFail)
+	}
+
+	decisions := map[string]staging.Resolution{}
+	for _, conflict := range conflicts {
+		resolution, err := resolve(conflict)
+		if err != nil {
+			return nil, err
+		}
+		if resolution == staging.ResolveFail {
+			return nil, fmt.Errorf("%s: %w", conflict.Path, staging.ErrConflict)
+		}
+		decisions[conflict.Path] = resolution
+	}
+	return staging.Decided(decisions, resolve), nil
+}
+
+// detectConflicts returns the files p writes that already exist in the
+// project directory with different content, without modifying it
+func detectConflicts(config *types.ProjectConfig, p *plan.Plan) ([]staging.Conflict, error) {
+	target, err := fsys.NewOS(config.Path)
+	if err != nil {
+		return nil, err
+	}
+	return staging.Detect(fsys.NewReadOnly(target), p)
+}
+
+// bindGenerateFlags registers the flags that control how a project is
+// generated on cmd
+func bindGenerateFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the files, directories and commands generating would produce, without touching the disk or network")
+	cmd.Flags().BoolVar(&planJSON, "json", false, "print the --dry-run plan as JSON")
+	cmd.Flags().BoolVar(&keepFailed, "keep-failed", false, "keep the staging directory of a failed generation for debugging")
+	cmd.Flags().StringVar(&onConflict, "on-conflict", "", "what to do with files that already exist: skip, overwrite, new (write as <file>.fsgo-new) or fail (default: ask, or fail without a terminal)")
+	_ = cmd.RegisterFlagCompletionFunc("on-conflict", cobra.FixedCompletions(
+		[]string{"skip", "overwrite", "new", "fail"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "stream the output of every external command instead of showing progress")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "output format: text, or json for newline-delimited JSON events (see docs/events.md)")
+	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(
+		[]string{"text", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.Flags().BoolVar(&strict, "strict", false, "fail when setting up Go dependencies fails, e.g. a go get, instead of only warning")
+	cmd.Flags().IntVar(&jobs, "jobs", 0, "how many generators to run at once, e.g. 1 to generate the backend and frontend one after the other (default: all)")
+	cmd.Flags().StringVar(&templateDir, "template-dir", "", "look up templates in this directory before the built-in ones (default: $FSGO_TEMPLATE_DIR, or templates in the fsgo config directory; see 'fsgo templates')")
+	cmd.Flags().StringVar(&templateSource, "template", "", "generate with a template pack from a directory or git repository, optionally pinned with #<ref> (see 'fsgo templates')")
+}
+
+// checkGenerateFlags rejects --json without --dry-run, unknown --on-conflict
+// resolutions and output formats, --output json with --dry-run, negative
+// --jobs, template directories that don't exist and malformed template packs
+func checkGenerateFlags() error {
+	if planJSON && !dryRun {
+		return usageErrorf("--json can only be used with --dry-run")
+	}
+	switch outputFormat {
+	case "text", "json":
+	default:
+		return usageErrorf("invalid --output %q: must be text or json", outputFormat)
+	}
+	if jsonOutput() && dryRun {
+		return usageErrorf("--output json can't be used with --dry-run; use --dry-run --json to print the plan as JSON")
+	}
+	if jobs < 0 {
+		return usageErrorf("invalid --jobs %d: must be at least 1, or 0 for no limit", jobs)
+	}
+	if onConflict != "" {
+		if _, err := staging.ParseResolution(onConflict); err != nil {
+			return usageErrorf("%v", err)
+		}
+	}
+	if templateSource != "" {
+		if _, err := pack.ParseSource(templateSource); err != nil {
+			return err
+		}
+	}
+	_, err := templateOverrideDir()
+	return err
+}
+
+// printPlan prints the plan for config to stdout, as a tree or as JSON. The
+// tree is followed by the files that already exist and the missing tools.
+func printPlan(ctx context.Context, projectGen *generator.ProjectGenerator, config *types.ProjectConfig) error {
+	p, err := projectGen.Plan(ctx, config)
+	if err != nil {
+		return err
+	}
+	if planJSON {
+		return p.PrintJSON(os.Stdout, config.Path)
+	}
+	p.Print(os.Stdout, config.Path)
+
+	conflicts, err := detectConflicts(config, p)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		fmt.Println("\nThese files already exist and would need --on-conflict or a choice when generating:")
+		for _, conflict := range conflicts {
+			fmt.Printf("  %s\n", conflict.Path)
+		}
+	}
+
+	var missing *types.MissingToolError
+	if err := projectGen.CheckTools(config, p); errors.As(err, &missing) {
+		fmt.Printf("\nThese tools are not on PATH, so generating would fail: %s\n", strings.Join(missing.Tools, ", "))
+	}
+	return nil
+}
+
+// Exit codes, as documented in the README. Scripts can rely on them staying
+// the same.
+const (
+	exitOK                = 0
+	exitFailure           = 1
+	exitUsage             = 2
+	exitInvalidConfig     = 3
+	exitUnknownFramework  = 4
+	exitMissingTool       = 5
+	exitDependencyInstall = 6
+	exitConflict          = 7
+	exitInterrupted       = 130
+)
+
+// usageError is a flag or argument that can't be used
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+// usageErrorf returns a usageError with a formatted message
+func usageErrorf(format string, args ...any) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// exitCode returns the exit code for err, classified by the errors it wraps
+func exitCode(err error) int {
+	var usage *usageError
+	var notEmpty *validate.DirNotEmptyError
+	switch {
+	case err == nil, errors.Is(err, tui.ErrAborted):
+		return exitOK
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case errors.As(err, &usage):
+		return exitUsage
+	case errors.Is(err, types.ErrUnknownFramework):
+		return exitUnknownFramework
+	case errors.Is(err, types.ErrInvalidConfig):
+		return exitInvalidConfig
+	case errors.Is(err, types.ErrMissingTool):
+		return exitMissingTool
+	case errors.Is(err, types.ErrDependencyInstall):
+		return exitDependencyInstall
+	case errors.Is(err, staging.ErrConflict), errors.As(err, &notEmpty):
+		return exitConflict
+	default:
+		return exitFailure
+	}
+}
+
+// exitWithError prints err prefixed with message and exits with the code
+// exitCode classifies it with. Aborting the wizard is not an error and only
+// prints abortedMessage; interrupting the generation exits with the
+// conventional status 130.
+func exitWithError(message string, err error) {
+	text, code := fmt.Sprintf("%s: %v", message, err), exitCode(err)
+	switch code {
+	case exitOK:
+		text = abortedMessage
+	case exitInterrupted:
+		text = interruptedMessage
+	}
+
+	if jsonOutput() {
+		events.NewJSON(os.Stdout).Handle(events.Event{Type: events.Error, Message: text, ExitCode: events.Int(code)})
+	} else {
+		fmt.Println(text)
+	}
+	os.Exit(code)
+}
+
+// jsonOutput reports whether --output json asks for a stream of events
+// instead of text
+func jsonOutput() bool {
+	return outputFormat == "json"
+}
+
+// canPrompt reports whether the user can be asked questions: stdin is a
+// terminal and stdout is not a stream of events
+func canPrompt() bool {
+	return !jsonOutput() && utils.IsTerminal(os.Stdin)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/verse91/fsgo-dev-kit/internal/spec"
+	"github.com/verse91/fsgo-dev-kit/internal/staging"
+	"github.com/verse91/fsgo-dev-kit/internal/tui"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/internal/validate"
+)
+
+func TestExitCode(t *testing.T) {
+	unknown := &types.UnknownFrameworkError{Kind: "backend", Name: "rails"}
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, 0},
+		{"aborted", tui.ErrAborted, 0},
+		{"wrapped aborted", fmt.Errorf("error getting project configuration: %w", tui.ErrAborted), 0},
+		{"other", errors.New("disk full"), 1},
+		{"usage", usageErrorf("invalid --jobs %d", -1), 2},
+		{"wrapped usage", fmt.Errorf("error: %w", usageErrorf("bad flag")), 2},
+		{"invalid config", types.ErrInvalidConfig, 3},
+		{"config error", &types.ConfigError{Message: "bad spec"}, 3},
+		{"spec error", spec.Errors{{File: "fsgo.yaml", Line: 2, Msg: "unknown field"}}, 3},
+		{"unknown framework", unknown, 4},
+		{"wrapped unknown framework", fmt.Errorf("%w (available: fiber, gin)", unknown), 4},
+		{"spec with an unknown framework", spec.Errors{
+			{File: "fsgo.yaml", Line: 1, Msg: "unknown field"},
+			{File: "fsgo.yaml", Line: 2, Msg: unknown.Error(), Err: unknown},
+		}, 4},
+		{"missing tool", &types.MissingToolError{Tools: []string{"bun"}}, 5},
+		{"wrapped missing tool", fmt.Errorf("error checking tools: %w", &types.MissingToolError{Tools: []string{"go"}}), 5},
+		{"dependency install", fmt.Errorf("go get failed: %w", types.ErrDependencyInstall), 6},
+		{"conflict", fmt.Errorf("README.md: %w", staging.ErrConflict), 7},
+		{"joined conflict", errors.Join(fmt.Errorf("error moving project into place: %w", staging.ErrConflict), errors.New("rollback failed")), 7},
+		{"directory not empty", fmt.Errorf("%w (pass --force)", &validate.DirNotEmptyError{Path: "shop"}), 7},
+		{"cancelled", context.Canceled, 130},
+		{"wrapped cancelled", fmt.Errorf("error running go mod init: %w", context.Canceled), 130},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
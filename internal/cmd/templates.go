@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/verse91/fsgo-dev-kit/internal/generator"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/internal/userconfig"
+)
+
+var (
+	// templateDir is the directory of template overrides given by --template-dir
+	templateDir string
+	// exportDir is where templates export writes the templates
+	exportDir string
+	// exportForce overwrites templates that were exported before
+	exportForce bool
+)
+
+// templatesCmd groups the commands that manage template overrides
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Customise the templates generated files are rendered from",
+	Long: `Every generator renders its files from a template set: fiber, gin, echo,
+next, react and svelte, plus root for the files in the project root.
+
+fsgo looks up templates in an override directory before the built-in ones,
+path by path, so overriding server/pkg/response/response.go.tmpl of the fiber
+set changes that file and nothing else. The override directory is the first of:
+
+  --template-dir <dir>
+  $FSGO_TEMPLATE_DIR
+  the templates directory of the fsgo config directory ($FSGO_CONFIG_DIR, or
+  fsgo under the user config directory)
+
+It holds one directory per set, laid out like 'fsgo templates export' writes
+them.
+
+A template pack, given with --template <dir|git-url>[#ref], is looked up after
+the override directory and before the built-in templates. Its templates
+directory uses the same layout, and its fsgo-pack.yaml can define additional
+backends and frontends.`,
+}
+
+// templatesListCmd lists the template sets
+var templatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the template sets and where overrides are read from",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := templateOverrideDir()
+		if err != nil {
+			exitWithError("Error listing templates", err)
+		}
+		library := templates.Builtin()
+		if dir != "" {
+			library = templates.NewLibrary(dir)
+		}
+		for _, name := range library.Sets() {
+			fmt.Println(name)
+		}
+		if dir != "" {
+			fmt.Printf("\nOverrides are read from %s\n", dir)
+		}
+	},
+}
+
+// templatesExportCmd writes a built-in template set to the override directory
+var templatesExportCmd = &cobra.Command{
+	Use:   "export <framework>",
+	Short: "Export the built-in templates of a framework to customise them",
+	Long: `Write the built-in templates of a framework, or of the project root with
+"root", to the override directory as a starting point for customising them.
+
+Templates are written to <dir>/<set>/, where dir is --dir or else the override
+directory fsgo reads from. Delete the exported templates you don't change, so
+they keep following fsgo updates.
+
+Examples:
+  fsgo templates export fiber
+  fsgo templates export next --dir ./team-templates`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: templates.Builtin().Sets(),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runTemplatesExport(args[0]); err != nil {
+			exitWithError("Error exporting templates", err)
+		}
+	},
+}
+
+func init() {
+	templatesExportCmd.Flags().StringVar(&exportDir, "dir", "", "directory to export to (default: the override directory)")
+	templatesExportCmd.Flags().BoolVar(&exportForce, "force", false, "overwrite templates that already exist")
+	templatesCmd.PersistentFlags().StringVar(&templateDir, "template-dir", "", "directory of template overrides")
+
+	templatesCmd.AddCommand(templatesListCmd, templatesExportCmd)
+	rootCmd.AddCommand(templatesCmd)
+}
+
+// runTemplatesExport exports the template set of framework
+func runTemplatesExport(framework string) error {
+	name := templateSetName(generator.NewGeneratorRegistry(), framework)
+
+	dir := exportDir
+	if dir == "" {
+		var err error
+		if dir, err = templateOverridePath(); err != nil {
+			return err
+		}
+	}
+
+	written, err := templates.Export(name, dir, exportForce)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Exported %d templates of %s to %s\n", len(written), name, dir)
+	fmt.Println("Delete the ones you don't change so they keep following fsgo updates.")
+	return nil
+}
+
+// templateSetName returns the template set of the framework named name,
+// e.g. next for "Next.js". Other names are returned lower-cased, as set
+// names such as root.
+func templateSetName(registry *generator.GeneratorRegistry, name string) string {
+	if framework, err := registry.ParseBackendFramework(name); err == nil {
+		if gen, exists := registry.GetBackendGenerator(framework); exists {
+			return gen.Describe().Name
+		}
+	}
+	if framework, err := registry.ParseFrontendFramework(name); err == nil {
+		if gen, exists := registry.GetFrontendGenerator(framework); exists {
+			return gen.Describe().Name
+		}
+	}
+	return strings.ToLower(name)
+}
+
+// templateOverridePath returns the directory template overrides are read
+// from, whether it exists or not: --template-dir, $FSGO_TEMPLATE_DIR or the
+// templates directory of the fsgo config directory
+func templateOverridePath() (string, error) {
+	if templateDir != "" {
+		return templateDir, nil
+	}
+	if dir := os.Getenv(userconfig.TemplateDirEnv); dir != "" {
+		return dir, nil
+	}
+	return userconfig.TemplatesPath()
+}
+
+// templateOverrideDir returns the directory template overrides are read from,
+// or "" if there is none. A directory given with --template-dir or
+// $FSGO_TEMPLATE_DIR must exist; the one in the config directory is optional.
+func templateOverrideDir() (string, error) {
+	dir, err := templateOverridePath()
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(dir)
+	if err == nil && info.IsDir() {
+		return dir, nil
+	}
+	if templateDir != "" || os.Getenv(userconfig.TemplateDirEnv) != "" {
+		return "", &types.ConfigError{Message: fmt.Sprintf("template directory %s does not exist", dir)}
+	}
+	return "", nil
+}
@@ -0,0 +1,173 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// context is the number of unchanged lines shown around each change
+const context = 3
+
+// maxCells bounds the size of the table used to compare two files. Larger
+// files are shown as entirely replaced instead of being compared line by line.
+const maxCells = 4_000_000
+
+// opKind is the kind of an edit
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// op is a line kept, deleted from the old text or inserted from the new one
+type op struct {
+	kind opKind
+	line string
+}
+
+// Unified returns the differences between oldText and newText in unified
+// diff format, labelled with oldName and newName. It returns "" when the
+// texts are equal.
+func Unified(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	ops := compare(splitLines(oldText), splitLines(newText))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks(ops) {
+		writeHunk(&b, ops, h)
+	}
+	return b.String()
+}
+
+// splitLines splits text into lines without their line endings
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// compare returns the edits turning a into b, based on their longest common
+// subsequence of lines
+func compare(a, b []string) []op {
+	if len(a)*len(b) > maxCells {
+		ops := make([]op, 0, len(a)+len(b))
+		for _, line := range a {
+			ops = append(ops, op{opDelete, line})
+		}
+		for _, line := range b {
+			ops = append(ops, op{opInsert, line})
+		}
+		return ops
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{opEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{opDelete, a[i]})
+			i++
+		default:
+			ops = append(ops, op{opInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{opDelete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{opInsert, b[j]})
+	}
+	return ops
+}
+
+// hunk is a range of ops shown together
+type hunk struct {
+	start, end int
+}
+
+// hunks groups the changes in ops with their surrounding context, merging
+// groups whose context overlaps
+func hunks(ops []op) []hunk {
+	var result []hunk
+	for i, o := range ops {
+		if o.kind == opEqual {
+			continue
+		}
+		start := max(i-context, 0)
+		end := min(i+context+1, len(ops))
+		if n := len(result); n > 0 && start <= result[n-1].end {
+			result[n-1].end = max(result[n-1].end, end)
+			continue
+		}
+		result = append(result, hunk{start, end})
+	}
+	return result
+}
+
+// writeHunk writes the header and lines of h
+func writeHunk(b *strings.Builder, ops []op, h hunk) {
+	// Line numbers are 1-based and count the lines before the hunk
+	oldLine, newLine := 1, 1
+	for _, o := range ops[:h.start] {
+		if o.kind != opInsert {
+			oldLine++
+		}
+		if o.kind != opDelete {
+			newLine++
+		}
+	}
+	oldCount, newCount := 0, 0
+	for _, o := range ops[h.start:h.end] {
+		if o.kind != opInsert {
+			oldCount++
+		}
+		if o.kind != opDelete {
+			newCount++
+		}
+	}
+	if oldCount == 0 {
+		oldLine--
+	}
+	if newCount == 0 {
+		newLine--
+	}
+
+	fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", oldLine, oldCount, newLine, newCount)
+	for _, o := range ops[h.start:h.end] {
+		prefix := " "
+		switch o.kind {
+		case opDelete:
+			prefix = "-"
+		case opInsert:
+			prefix = "+"
+		}
+		b.WriteString(prefix + o.line + "\n")
+	}
+}
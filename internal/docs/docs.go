@@ -0,0 +1,73 @@
+package docs
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+// ProjectTopic documents the layout and Makefile shared by every project
+const ProjectTopic = "project"
+
+//go:embed content/*.md
+var content embed.FS
+
+// Topics returns the names of all embedded documentation topics, sorted
+func Topics() []string {
+	entries, err := fs.ReadDir(content, "content")
+	if err != nil {
+		return nil
+	}
+
+	topics := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		topics = append(topics, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// Lookup resolves a user-supplied name such as "Next.js", "nextjs" or "next"
+// to a topic name. Names are compared like framework names, with
+// types.SameName, and may add "js" to the topic.
+func Lookup(name string) (string, error) {
+	for _, topic := range Topics() {
+		if types.SameName(topic, name) || types.SameName(topic+"js", name) {
+			return topic, nil
+		}
+	}
+	return "", fmt.Errorf("no documentation for %q (available: %s)", name, strings.Join(Topics(), ", "))
+}
+
+// Markdown returns the markdown source of a topic. Topics are templates
+// rendered against the data of a project, so they only describe what that
+// project has, such as its package manager and Makefile targets.
+func Markdown(topic string) ([]byte, error) {
+	data, err := content.ReadFile(path.Join("content", topic+".md"))
+	if err != nil {
+		return nil, fmt.Errorf("no documentation for %q", topic)
+	}
+	return data, nil
+}
+
+// Render renders markdown for the terminal, wrapped at width. With plain set
+// the output contains no colors or other ANSI escapes.
+func Render(markdown []byte, width int, plain bool) (string, error) {
+	style := glamour.WithAutoStyle()
+	if plain {
+		style = glamour.WithStandardStyle("notty")
+	}
+
+	renderer, err := glamour.NewTermRenderer(style, glamour.WithWordWrap(width))
+	if err != nil {
+		return "", fmt.Errorf("error creating markdown renderer: %v", err)
+	}
+	return renderer.Render(string(markdown))
+}
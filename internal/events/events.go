@@ -0,0 +1,167 @@
+// Package events describes what happens during generation as a stream of
+// events. Generation reports every step, file, command and warning as an
+// event; a Handler renders them, for people as progress or for tools as
+// newline-delimited JSON. The JSON schema is documented in docs/events.md.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/verse91/fsgo-dev-kit/internal/spec"
+)
+
+// SchemaVersion is the version of the JSON event schema. It is increased
+// whenever a field or event type is removed or changes meaning; new fields
+// and event types may be added without a new version.
+const SchemaVersion = 1
+
+// Type identifies what an event reports
+type Type string
+
+// Event types
+const (
+	// ConfigResolved reports the final project configuration
+	ConfigResolved Type = "config_resolved"
+	// StepStarted reports that a step of a generator began
+	StepStarted Type = "step_started"
+	// StepFinished reports the status and duration of a step
+	StepFinished Type = "step_finished"
+	// DirCreated reports a directory created in the project
+	DirCreated Type = "dir_created"
+	// FileWritten reports a file written in the project
+	FileWritten Type = "file_written"
+	// CommandStarted reports that an external command began
+	CommandStarted Type = "command_started"
+	// CommandFinished reports the exit code and duration of a command
+	CommandFinished Type = "command_finished"
+	// Output is one line of output of an external command
+	Output Type = "output"
+	// Warning reports a problem that doesn't stop generation
+	Warning Type = "warning"
+	// Notice is informational text, such as where a failed generation was kept
+	Notice Type = "notice"
+	// Error reports why fsgo failed; it is the last event
+	Error Type = "error"
+	// Done reports that the project was generated; it is the last event
+	Done Type = "done"
+)
+
+// Status is the outcome of a step or command
+type Status string
+
+// Statuses
+const (
+	StatusOK        Status = "ok"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// StatusOf returns the status of a step or command that ended with err
+func StatusOf(err error) Status {
+	switch {
+	case err == nil:
+		return StatusOK
+	case errors.Is(err, context.Canceled):
+		return StatusCancelled
+	default:
+		return StatusFailed
+	}
+}
+
+// Event is something that happened during generation. Only the fields that
+// apply to its Type are set.
+type Event struct {
+	Type Type `json:"type"`
+	// Group is the generator the event belongs to, e.g. backend or frontend
+	Group string `json:"group,omitempty"`
+	// Step is the step an event of a step belongs to
+	Step string `json:"step,omitempty"`
+	// Status is the outcome of a finished step or command
+	Status Status `json:"status,omitempty"`
+	// Path is the slash-separated path of a directory or file relative to
+	// the project, or the project directory of a done event
+	Path string `json:"path,omitempty"`
+	// Size is the number of bytes of a written file
+	Size *int `json:"size,omitempty"`
+	// Mode is the octal permission of a directory or file
+	Mode string `json:"mode,omitempty"`
+	// Command is the command line of a command
+	Command string `json:"command,omitempty"`
+	// Dir is the working directory of a command, relative to the project
+	Dir string `json:"dir,omitempty"`
+	// ExitCode is the exit code of a finished command, or of fsgo for an
+	// error event. Commands that were killed or could not start have -1.
+	ExitCode *int `json:"exit_code,omitempty"`
+	// Duration is how long a step or command took
+	Duration time.Duration `json:"-"`
+	// Line is a line of command output, without the newline
+	Line string `json:"line,omitempty"`
+	// Message describes a warning, notice or error
+	Message string `json:"message,omitempty"`
+	// Config is the configuration of a config_resolved event
+	Config *spec.Document `json:"config,omitempty"`
+	// Name is the project name of a done event
+	Name string `json:"name,omitempty"`
+	// Log is the transcript of the commands run, relative to the working
+	// directory
+	Log string `json:"log,omitempty"`
+	// NextSteps are the commands to run the generated project
+	NextSteps []string `json:"next_steps,omitempty"`
+	// Warnings repeats the warnings of the generation in a done event
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Int returns a pointer to n, for the optional numeric fields of an event
+func Int(n int) *int {
+	return &n
+}
+
+// Handler receives the events of a generation as they happen. Events may be
+// handled from several goroutines at once.
+type Handler interface {
+	Handle(event Event)
+}
+
+// JSON writes events as newline-delimited JSON objects, each stamped with
+// the schema version and the time it was handled
+type JSON struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewJSON returns a handler writing events to out
+func NewJSON(out io.Writer) *JSON {
+	return &JSON{out: out}
+}
+
+// Handle writes event as a single line
+func (j *JSON) Handle(event Event) {
+	type fields Event
+	line := struct {
+		Version int    `json:"v"`
+		Time    string `json:"time"`
+		fields
+		DurationMS *int64 `json:"duration_ms,omitempty"`
+	}{
+		Version: SchemaVersion,
+		Time:    time.Now().UTC().Format(time.RFC3339Nano),
+		fields:  fields(event),
+	}
+	if event.Type == StepFinished || event.Type == CommandFinished {
+		ms := event.Duration.Milliseconds()
+		line.DurationMS = &ms
+	}
+
+	data, err := json.Marshal(line)
+	if err != nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.out.Write(append(data, '\n'))
+}
@@ -0,0 +1,74 @@
+package events_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/verse91/fsgo-dev-kit/internal/events"
+)
+
+// timeField matches the time every JSON event is stamped with
+var timeField = regexp.MustCompile(`"time":"([^"]*)"`)
+
+// golden is the JSON stream of a generation that ran a step with a command
+// and finished, followed by the error of another run, with times replaced by
+// "…" as in docs/events.md
+const golden = `{"v":1,"time":"…","type":"step_started","group":"backend","step":"Initializing Go module"}
+{"v":1,"time":"…","type":"dir_created","group":"backend","step":"Initializing Go module","path":"server","mode":"0755"}
+{"v":1,"time":"…","type":"file_written","group":"backend","step":"Initializing Go module","path":"server/main.go","size":0,"mode":"0644"}
+{"v":1,"time":"…","type":"command_started","group":"backend","step":"Initializing Go module","command":"go mod init 'my app/server'","dir":"server"}
+{"v":1,"time":"…","type":"output","group":"backend","step":"Initializing Go module","line":"go: creating new go.mod"}
+{"v":1,"time":"…","type":"command_finished","group":"backend","step":"Initializing Go module","status":"ok","command":"go mod init 'my app/server'","dir":"server","exit_code":0,"duration_ms":1003}
+{"v":1,"time":"…","type":"warning","group":"backend","step":"Initializing Go module","message":"go get go.uber.org/zap failed: exit status 1"}
+{"v":1,"time":"…","type":"step_finished","group":"backend","step":"Initializing Go module","status":"ok","duration_ms":0}
+{"v":1,"time":"…","type":"done","path":"my-app","name":"my-app","log":"my-app/.fsgo/logs/generate.log","next_steps":["make run"],"warnings":["go get go.uber.org/zap failed: exit status 1"]}
+{"v":1,"time":"…","type":"error","exit_code":130,"message":"Error generating project: context canceled"}
+`
+
+func TestJSON(t *testing.T) {
+	const group, step = "backend", "Initializing Go module"
+	command := "go mod init 'my app/server'"
+	warning := "go get go.uber.org/zap failed: exit status 1"
+
+	var out strings.Builder
+	handler := events.NewJSON(&out)
+	for _, event := range []events.Event{
+		{Type: events.StepStarted, Group: group, Step: step},
+		{Type: events.DirCreated, Group: group, Step: step, Path: "server", Mode: "0755"},
+		{Type: events.FileWritten, Group: group, Step: step, Path: "server/main.go", Size: events.Int(0), Mode: "0644"},
+		// Durations are only written for finished steps and commands
+		{Type: events.CommandStarted, Group: group, Step: step, Command: command, Dir: "server", Duration: time.Second},
+		{Type: events.Output, Group: group, Step: step, Line: "go: creating new go.mod"},
+		{Type: events.CommandFinished, Group: group, Step: step, Status: events.StatusOK, Command: command, Dir: "server", ExitCode: events.Int(0), Duration: 1003 * time.Millisecond},
+		{Type: events.Warning, Group: group, Step: step, Message: warning},
+		{Type: events.StepFinished, Group: group, Step: step, Status: events.StatusOK},
+		{Type: events.Done, Path: "my-app", Name: "my-app", Log: "my-app/.fsgo/logs/generate.log", NextSteps: []string{"make run"}, Warnings: []string{warning}},
+		{Type: events.Error, ExitCode: events.Int(130), Message: "Error generating project: context canceled"},
+	} {
+		handler.Handle(event)
+	}
+
+	got := out.String()
+	for i, line := range strings.Split(strings.TrimSuffix(got, "\n"), "\n") {
+		var object map[string]any
+		if err := json.Unmarshal([]byte(line), &object); err != nil {
+			t.Fatalf("line %d is not a JSON object: %v\n%s", i+1, err, line)
+		}
+		match := timeField.FindStringSubmatch(line)
+		if match == nil {
+			t.Fatalf("line %d has no time: %s", i+1, line)
+		}
+		stamp, err := time.Parse(time.RFC3339Nano, match[1])
+		if err != nil || stamp.Location() != time.UTC {
+			t.Errorf("line %d has time %q, want RFC 3339 in UTC", i+1, match[1])
+		}
+	}
+
+	got = timeField.ReplaceAllString(got, `"time":"…"`)
+	if got != golden {
+		t.Errorf("JSON events:\n%s\nwant:\n%s", got, golden)
+	}
+}
@@ -0,0 +1,32 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/runner"
+)
+
+// goTimeout limits each go command, which may have to download modules
+const goTimeout = 5 * time.Minute
+
+// planServer adds the server directory to p along with the commands that
+// initialize its Go module at modulePath and install deps into it, as two
+// steps
+func planServer(p *plan.Plan, modulePath string, deps []string) {
+	p.Step("Initializing Go module")
+	p.Mkdir("server", plan.DirMode)
+	server := p.Sub("server")
+
+	// Go module setup failures are reported but don't stop the generation
+	server.RunOptional(goCommand("mod", "init", modulePath))
+	p.Step("Installing Go dependencies")
+	for _, dep := range deps {
+		server.RunOptional(goCommand("get", dep))
+	}
+}
+
+// goCommand returns the go command with args
+func goCommand(args ...string) runner.Command {
+	return runner.Command{Name: "go", Args: args, Timeout: goTimeout}
+}
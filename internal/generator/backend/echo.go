@@ -1,10 +1,11 @@
 package backend
 
 import (
-	"fmt"
+	"context"
 
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
 	"github.com/verse91/fsgo-dev-kit/internal/types"
-	"github.com/verse91/fsgo-dev-kit/pkg/utils"
 )
 
 // EchoGenerator handles Echo backend generation
@@ -15,6 +16,24 @@ func NewEchoGenerator() *EchoGenerator {
 	return &EchoGenerator{}
 }
 
+// Describe returns the Echo generator's descriptor
+func (g *EchoGenerator) Describe() types.GeneratorDescriptor {
+	return types.GeneratorDescriptor{
+		Name:          "echo",
+		DisplayName:   string(g.GetFramework()),
+		Description:   "High-performance, extensible web framework with built-in middleware",
+		Homepage:      "https://echo.labstack.com",
+		RequiredTools: []string{"go"},
+		Options:       []types.ConfigField{},
+		Dependencies:  g.GetDependencies(),
+		Layout: []string{
+			"server/go.mod",
+			"server/main.go",
+			"server/.env",
+		},
+	}
+}
+
 // GetFramework returns the framework name
 func (g *EchoGenerator) GetFramework() types.BackendFramework {
 	return types.Echo
@@ -30,116 +49,15 @@ func (g *EchoGenerator) GetDependencies() []string {
 	}
 }
 
-// Generate creates a new Echo backend project
-func (g *EchoGenerator) Generate(config *types.ProjectConfig) error {
-	fmt.Println("🚀 Creating Echo backend...")
-
-	// Create server directory
-	serverDir := "server"
-	if err := utils.CreateDirectory(serverDir, 0o755); err != nil {
-		return fmt.Errorf("error creating server directory: %v", err)
-	}
-
-	// Change to server directory
-	if err := utils.ChangeDir(serverDir); err != nil {
-		return fmt.Errorf("error changing to server directory: %v", err)
-	}
-
-	// Initialize Go module
-	if err := utils.RunCommand("go", "mod", "init", "server"); err != nil {
-		fmt.Printf("Error initializing Go module: %v\n", err)
-	}
-
-	// Install dependencies
-	if err := g.installDependencies(); err != nil {
-		return fmt.Errorf("error installing dependencies: %v", err)
-	}
-
-	// Create basic structure and files
-	if err := g.createBasicStructure(); err != nil {
-		return fmt.Errorf("error creating basic structure: %v", err)
-	}
-
-	// Go back to project root
-	if err := utils.ChangeDir(".."); err != nil {
-		return fmt.Errorf("error returning to project root: %v", err)
-	}
-
-	return nil
-}
-
-// installDependencies installs Go dependencies
-func (g *EchoGenerator) installDependencies() error {
-	deps := g.GetDependencies()
-
-	for _, dep := range deps {
-		if err := utils.RunCommand("go", "get", dep); err != nil {
-			fmt.Printf("Error installing dependency %s: %v\n", dep, err)
-		}
-	}
-
-	return nil
-}
-
-// createBasicStructure creates basic structure for Echo
-func (g *EchoGenerator) createBasicStructure() error {
-	// Create basic main.go for Echo
-	mainGoContent := `package main
-
-import (
-	"net/http"
-	"os"
-
-	"github.com/joho/godotenv"
-	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
-)
-
-func main() {
-	// Load environment variables
-	if err := godotenv.Load(); err != nil {
-		// log.Println("No .env file found")
-	}
+// Plan adds the actions creating a new Echo backend project to p
+func (g *EchoGenerator) Plan(ctx context.Context, config *types.ProjectConfig, p *plan.Plan) error {
+	data := templates.NewData(ctx, config)
 
-	// Create Echo instance
-	e := echo.New()
+	// Create server directory, initialize the Go module and install dependencies
+	planServer(p, data.ModulePath, g.GetDependencies())
 
-	// Middleware
-	e.Use(middleware.Logger())
-	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
+	p.Step("Writing Echo server files")
 
-	// Health check endpoint
-	e.GET("/api/v1/health", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]interface{}{
-			"status":  "ok",
-			"message": "Server is running",
-		})
-	})
-
-	// Get port from environment or use default
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
-
-	// Start server
-	e.Logger.Fatal(e.Start(":" + port))
+	// Create main.go and the environment files
+	return templates.PlanSet(ctx, p, "echo", data)
 }
-`
-
-	if err := utils.CreateFile("main.go", mainGoContent); err != nil {
-		return fmt.Errorf("error creating main.go: %v", err)
-	}
-
-	// Create .env files
-	envContent := "PORT=8080\nENV=development\n"
-	if err := utils.CreateFile(".env", envContent); err != nil {
-		return fmt.Errorf("error creating .env: %v", err)
-	}
-	if err := utils.CreateFile(".env.example", envContent); err != nil {
-		return fmt.Errorf("error creating .env.example: %v", err)
-	}
-
-	return nil
-}
\ No newline at end of file
@@ -1,11 +1,11 @@
 package backend
 
 import (
-	"fmt"
+	"context"
 
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
 	"github.com/verse91/fsgo-dev-kit/internal/templates"
 	"github.com/verse91/fsgo-dev-kit/internal/types"
-	"github.com/verse91/fsgo-dev-kit/pkg/utils"
 )
 
 // FiberGenerator handles Go Fiber backend generation
@@ -16,57 +16,45 @@ func NewFiberGenerator() *FiberGenerator {
 	return &FiberGenerator{}
 }
 
-// Generate creates a new Go Fiber backend project
-func (g *FiberGenerator) Generate(config *types.ProjectConfig) error {
-	fmt.Println("🚀 Creating Go Fiber backend...")
+// Plan adds the actions creating a new Go Fiber backend project to p
+func (g *FiberGenerator) Plan(ctx context.Context, config *types.ProjectConfig, p *plan.Plan) error {
+	data := templates.NewData(ctx, config)
 
-	// Create server directory
-	serverDir := "server"
-	if err := utils.CreateDirectory(serverDir, 0o755); err != nil {
-		return fmt.Errorf("error creating server directory: %v", err)
-	}
-
-	// Change to server directory
-	if err := utils.ChangeDir(serverDir); err != nil {
-		return fmt.Errorf("error changing to server directory: %v", err)
-	}
-
-	// Initialize Go module
-	if err := utils.RunCommand("go", "mod", "init", "server"); err != nil {
-		fmt.Printf("Error initializing Go module: %v\n", err)
-	}
-
-	// Install dependencies
-	if err := g.installDependencies(); err != nil {
-		return fmt.Errorf("error installing dependencies: %v", err)
-	}
-
-	// Create backend structure
-	if err := g.createDirectoryStructure(); err != nil {
-		return fmt.Errorf("error creating directory structure: %v", err)
-	}
-
-	// Create Go files
-	if err := g.createGoFiles(); err != nil {
-		return fmt.Errorf("error creating Go files: %v", err)
-	}
+	// Create server directory, initialize the Go module and install dependencies
+	planServer(p, data.ModulePath, g.GetDependencies())
 
-	// Create configuration files
-	if err := g.createConfigFiles(); err != nil {
-		return fmt.Errorf("error creating config files: %v", err)
-	}
+	p.Step("Writing Go Fiber server files")
 
-	// Create environment files
-	if err := g.createEnvFiles(); err != nil {
-		return fmt.Errorf("error creating environment files: %v", err)
-	}
+	// Create the backend structure, Go sources, configuration and environment files
+	return templates.PlanSet(ctx, p, "fiber", data)
+}
 
-	// Go back to project root
-	if err := utils.ChangeDir(".."); err != nil {
-		return fmt.Errorf("error returning to project root: %v", err)
+// Describe returns the Fiber generator's descriptor
+func (g *FiberGenerator) Describe() types.GeneratorDescriptor {
+	return types.GeneratorDescriptor{
+		Name:          "fiber",
+		DisplayName:   string(g.GetFramework()),
+		Description:   "Express-inspired web framework built on fasthttp, with a layered project structure",
+		Homepage:      "https://gofiber.io",
+		RequiredTools: []string{"go"},
+		Options:       []types.ConfigField{},
+		Dependencies:  g.GetDependencies(),
+		Layout: []string{
+			"server/go.mod",
+			"server/cmd/server/main.go",
+			"server/api/",
+			"server/internal/config/",
+			"server/internal/controller/",
+			"server/internal/middleware/",
+			"server/internal/routes/",
+			"server/internal/service/",
+			"server/pkg/",
+			"server/db/migrations/",
+			"server/Dockerfile",
+			"server/.air.toml",
+			"server/.env",
+		},
 	}
-
-	return nil
 }
 
 // GetFramework returns the framework name
@@ -84,101 +72,3 @@ func (g *FiberGenerator) GetDependencies() []string {
 		"github.com/gofiber/cors",
 	}
 }
-
-// installDependencies installs Go dependencies
-func (g *FiberGenerator) installDependencies() error {
-	deps := g.GetDependencies()
-
-	for _, dep := range deps {
-		if err := utils.RunCommand("go", "get", dep); err != nil {
-			fmt.Printf("Error installing dependency %s: %v\n", dep, err)
-		}
-	}
-
-	return nil
-}
-
-// createDirectoryStructure creates the backend directory structure
-func (g *FiberGenerator) createDirectoryStructure() error {
-	dirs := []string{
-		"api",
-		"cmd/server/tmp",
-		"cmd/test",
-		"db/migrations",
-		"internal/config",
-		"internal/controller",
-		"internal/middleware",
-		"internal/model",
-		"internal/repo",
-		"internal/routes",
-		"internal/service",
-		"pkg/logger",
-		"pkg/response",
-		"pkg/utils",
-		"tmp",
-	}
-
-	for _, dir := range dirs {
-		if err := utils.CreateDirectory(dir, 0o755); err != nil {
-			return fmt.Errorf("error creating directory %s: %v", dir, err)
-		}
-	}
-
-	return nil
-}
-
-// createGoFiles creates all the Go source files
-func (g *FiberGenerator) createGoFiles() error {
-	files := map[string]func() string{
-		"cmd/server/main.go":                  templates.MainGoFile,
-		"api/api.go":                          templates.ApiGoFile,
-		"internal/config/config.go":           templates.ConfigGoFile,
-		"internal/routes/routes.go":           templates.RoutesGoFile,
-		"pkg/logger/zap.go":                   templates.LoggerGoFile,
-		"pkg/utils/env.go":                    templates.EnvUtilsGoFile,
-		"pkg/response/response.go":            templates.ResponseGoFile,
-		"pkg/response/httpStatusCode.go":      templates.HttpStatusCodeGoFile,
-		"internal/middleware/rate-limit.go":   templates.RateLimitMiddleware,
-		"internal/middleware/user-api-key.go": templates.ApiKeyMiddleware,
-		"cmd/test/db.go":                      templates.TestDbGoFile,
-		"db/connect.go":                       templates.DbConnectGoFile,
-		"db/migrations/migrate.go":            templates.MigrationsGoFile,
-		"db/migrations/schema.sql":            templates.SchemaSQLFile,
-		"Dockerfile":                          templates.DockerfileTemplate,
-	}
-
-	for path, templateFunc := range files {
-		if err := utils.CreateFile(path, templateFunc()); err != nil {
-			return fmt.Errorf("error creating file %s: %v", path, err)
-		}
-	}
-
-	return nil
-}
-
-// createConfigFiles creates configuration files
-func (g *FiberGenerator) createConfigFiles() error {
-	// Create .air.toml
-	if err := utils.CreateFile(".air.toml", templates.AirConfigTemplate()); err != nil {
-		return fmt.Errorf("error creating .air.toml: %v", err)
-	}
-
-	return nil
-}
-
-// createEnvFiles creates the environment files
-func (g *FiberGenerator) createEnvFiles() error {
-	files := map[string]func() string{
-		".env":         templates.BackendEnvFile,
-		".env.example": templates.BackendEnvExampleFile,
-	}
-
-	for path, templateFunc := range files {
-		if err := utils.CreateFile(path, templateFunc()); err != nil {
-			return fmt.Errorf("error creating file %s: %v", path, err)
-		}
-	}
-
-	return nil
-}
-
@@ -1,10 +1,11 @@
 package backend
 
 import (
-	"fmt"
+	"context"
 
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
 	"github.com/verse91/fsgo-dev-kit/internal/types"
-	"github.com/verse91/fsgo-dev-kit/pkg/utils"
 )
 
 // GinGenerator handles Gin backend generation
@@ -15,6 +16,24 @@ func NewGinGenerator() *GinGenerator {
 	return &GinGenerator{}
 }
 
+// Describe returns the Gin generator's descriptor
+func (g *GinGenerator) Describe() types.GeneratorDescriptor {
+	return types.GeneratorDescriptor{
+		Name:          "gin",
+		DisplayName:   string(g.GetFramework()),
+		Description:   "Fast HTTP web framework with a martini-like API and a minimal setup",
+		Homepage:      "https://gin-gonic.com",
+		RequiredTools: []string{"go"},
+		Options:       []types.ConfigField{},
+		Dependencies:  g.GetDependencies(),
+		Layout: []string{
+			"server/go.mod",
+			"server/main.go",
+			"server/.env",
+		},
+	}
+}
+
 // GetFramework returns the framework name
 func (g *GinGenerator) GetFramework() types.BackendFramework {
 	return types.Gin
@@ -30,117 +49,15 @@ func (g *GinGenerator) GetDependencies() []string {
 	}
 }
 
-// Generate creates a new Gin backend project
-func (g *GinGenerator) Generate(config *types.ProjectConfig) error {
-	fmt.Println("🚀 Creating Gin backend...")
-
-	// Create server directory
-	serverDir := "server"
-	if err := utils.CreateDirectory(serverDir, 0o755); err != nil {
-		return fmt.Errorf("error creating server directory: %v", err)
-	}
-
-	// Change to server directory
-	if err := utils.ChangeDir(serverDir); err != nil {
-		return fmt.Errorf("error changing to server directory: %v", err)
-	}
-
-	// Initialize Go module
-	if err := utils.RunCommand("go", "mod", "init", "server"); err != nil {
-		fmt.Printf("Error initializing Go module: %v\n", err)
-	}
-
-	// Install dependencies
-	if err := g.installDependencies(); err != nil {
-		return fmt.Errorf("error installing dependencies: %v", err)
-	}
-
-	// Create basic structure and files
-	if err := g.createBasicStructure(); err != nil {
-		return fmt.Errorf("error creating basic structure: %v", err)
-	}
-
-	// Go back to project root
-	if err := utils.ChangeDir(".."); err != nil {
-		return fmt.Errorf("error returning to project root: %v", err)
-	}
+// Plan adds the actions creating a new Gin backend project to p
+func (g *GinGenerator) Plan(ctx context.Context, config *types.ProjectConfig, p *plan.Plan) error {
+	data := templates.NewData(ctx, config)
 
-	return nil
-}
-
-// installDependencies installs Go dependencies
-func (g *GinGenerator) installDependencies() error {
-	deps := g.GetDependencies()
+	// Create server directory, initialize the Go module and install dependencies
+	planServer(p, data.ModulePath, g.GetDependencies())
 
-	for _, dep := range deps {
-		if err := utils.RunCommand("go", "get", dep); err != nil {
-			fmt.Printf("Error installing dependency %s: %v\n", dep, err)
-		}
-	}
+	p.Step("Writing Gin server files")
 
-	return nil
+	// Create main.go and the environment files
+	return templates.PlanSet(ctx, p, "gin", data)
 }
-
-// createBasicStructure creates basic structure for Gin
-func (g *GinGenerator) createBasicStructure() error {
-	// Create basic main.go for Gin
-	mainGoContent := `package main
-
-import (
-	"log"
-	"os"
-
-	"github.com/gin-gonic/gin"
-	"github.com/gin-contrib/cors"
-	"github.com/joho/godotenv"
-)
-
-func main() {
-	// Load environment variables
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
-	}
-
-	// Set Gin mode
-	gin.SetMode(gin.ReleaseMode)
-
-	// Create Gin router
-	r := gin.Default()
-
-	// CORS middleware
-	r.Use(cors.Default())
-
-	// Health check endpoint
-	r.GET("/api/v1/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "ok",
-			"message": "Server is running",
-		})
-	})
-
-	// Get port from environment or use default
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
-
-	log.Printf("Server starting on port %s", port)
-	log.Fatal(r.Run(":" + port))
-}
-`
-
-	if err := utils.CreateFile("main.go", mainGoContent); err != nil {
-		return fmt.Errorf("error creating main.go: %v", err)
-	}
-
-	// Create .env files
-	envContent := "PORT=8080\nENV=development\n"
-	if err := utils.CreateFile(".env", envContent); err != nil {
-		return fmt.Errorf("error creating .env: %v", err)
-	}
-	if err := utils.CreateFile(".env.example", envContent); err != nil {
-		return fmt.Errorf("error creating .env.example: %v", err)
-	}
-
-	return nil
-}
\ No newline at end of file
@@ -0,0 +1,51 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+// TemplateGenerator generates a backend that is defined entirely by its
+// descriptor and the template set named after it, such as a backend of a
+// template pack. The server is set up like the built-in ones: a Go module
+// with the descriptor's dependencies, followed by the files of the set.
+type TemplateGenerator struct {
+	descriptor types.GeneratorDescriptor
+}
+
+// NewTemplateGenerator creates a backend generator described by descriptor
+// that renders the template set descriptor.Name
+func NewTemplateGenerator(descriptor types.GeneratorDescriptor) *TemplateGenerator {
+	return &TemplateGenerator{descriptor: descriptor}
+}
+
+// Describe returns the generator's descriptor
+func (g *TemplateGenerator) Describe() types.GeneratorDescriptor {
+	return g.descriptor
+}
+
+// GetFramework returns the framework name, which is the display name of the
+// descriptor
+func (g *TemplateGenerator) GetFramework() types.BackendFramework {
+	return types.BackendFramework(g.descriptor.DisplayName)
+}
+
+// GetDependencies returns the dependencies listed by the descriptor
+func (g *TemplateGenerator) GetDependencies() []string {
+	return g.descriptor.Dependencies
+}
+
+// Plan adds the actions creating the backend to p
+func (g *TemplateGenerator) Plan(ctx context.Context, config *types.ProjectConfig, p *plan.Plan) error {
+	data := templates.NewData(ctx, config)
+
+	// Create server directory, initialize the Go module and install dependencies
+	planServer(p, data.ModulePath, g.GetDependencies())
+
+	p.Step("Writing " + g.descriptor.DisplayName + " server files")
+
+	return templates.PlanSet(ctx, p, g.descriptor.Name, data)
+}
@@ -0,0 +1,7 @@
+package frontend
+
+import "time"
+
+// createTimeout limits the create command of a frontend, which downloads and
+// installs all of its dependencies
+const createTimeout = 15 * time.Minute
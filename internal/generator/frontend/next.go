@@ -1,17 +1,16 @@
 package frontend
 
 import (
-	"fmt"
-	"os/exec"
-	"os"
+	"context"
 
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/runner"
 	"github.com/verse91/fsgo-dev-kit/internal/templates"
 	"github.com/verse91/fsgo-dev-kit/internal/types"
-	"github.com/verse91/fsgo-dev-kit/pkg/utils"
 )
 
 // NextJSGenerator handles Next.js frontend generation
-type NextJSGenerator struct {}
+type NextJSGenerator struct{}
 
 // NewNextJSGenerator creates a new Next.js frontend generator
 func NewNextJSGenerator() *NextJSGenerator {
@@ -28,124 +27,90 @@ func (g *NextJSGenerator) GetBuildCommands() []string {
 	return []string{"bun run build", "bun run start"}
 }
 
-// Generate creates a new Next.js frontend project
-func (g *NextJSGenerator) Generate(config *types.ProjectConfig) error {
-	fmt.Println("🚀 Creating Next.js frontend...")
-
-	// Build create command based on configuration
-	cmdArgs := g.buildCreateCommand(config.Frontend)
-	cmd := exec.Command("sh", "-c", cmdArgs)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("error creating Next.js project: %v", err)
-	}
-
-	// Change to client directory
-	if err := utils.ChangeDir("client"); err != nil {
-		return fmt.Errorf("error changing to client directory: %v", err)
-	}
-
-	// Create additional directory structure
-	if err := g.createDirectoryStructure(); err != nil {
-		return fmt.Errorf("error creating directory structure: %v", err)
-	}
-
-	// Create component files
-	if err := g.createComponents(); err != nil {
-		return fmt.Errorf("error creating components: %v", err)
+// GetCapabilities returns the options supported by create-next-app
+func (g *NextJSGenerator) GetCapabilities() types.FrontendCapabilities {
+	return types.FrontendCapabilities{
+		TypeScript:      true,
+		TailwindCSS:     true,
+		ESLint:          true,
+		PackageManagers: []types.PackageManager{types.Bun, types.Npm, types.Pnpm, types.Yarn},
 	}
-
-	// Create environment files
-	if err := g.createEnvFiles(); err != nil {
-		return fmt.Errorf("error creating environment files: %v", err)
-	}
-
-	// Go back to project root
-	if err := utils.ChangeDir(".."); err != nil {
-		return fmt.Errorf("error returning to project root: %v", err)
-	}
-
-	return nil
 }
 
-// buildCreateCommand builds the Next.js create command based on configuration
-func (g *NextJSGenerator) buildCreateCommand(frontend *types.FrontendConfig) string {
-	baseCmd := "yes \"\" | bun create next-app@latest client"
-	
-	if frontend.TypeScript {
-		baseCmd += " --typescript"
-	} else {
-		baseCmd += " --js"
-	}
-	
-	if frontend.ESLint {
-		baseCmd += " --eslint"
-	}
-	
-	if frontend.TailwindCSS {
-		baseCmd += " --tailwind"
+// Describe returns the Next.js generator's descriptor
+func (g *NextJSGenerator) Describe() types.GeneratorDescriptor {
+	capabilities := g.GetCapabilities()
+	return types.GeneratorDescriptor{
+		Name:            "next",
+		DisplayName:     string(g.GetFramework()),
+		Description:     "React framework using the App Router, with auth pages and UI components",
+		Homepage:        "https://nextjs.org",
+		RequiredTools:   []string{"node"},
+		Options:         capabilities.Options(),
+		PackageManagers: capabilities.PackageManagers,
+		Layout: []string{
+			"client/package.json",
+			"client/src/app/",
+			"client/src/app/auth/",
+			"client/src/components/",
+			"client/src/lib/",
+			"client/public/assets/",
+			"client/.env",
+		},
 	}
-	
-	baseCmd += " --app" // Always use App Router
-	return baseCmd
 }
 
-// createDirectoryStructure creates the frontend directory structure
-func (g *NextJSGenerator) createDirectoryStructure() error {
-	dirs := []string{
-		"public/assets/fonts/components-fonts",
-		"public/assets/fonts/logo-font", 
-		"public/assets/icons",
-		"src/app/auth/callback",
-		"src/app/auth/signin",
-		"src/components/homepage",
-		"src/components/ui/navbar",
-		"src/components/ui/texts",
-		"src/lib",
-		"src/styles",
-	}
+// Plan adds the actions creating a new Next.js frontend project to p
+func (g *NextJSGenerator) Plan(ctx context.Context, config *types.ProjectConfig, p *plan.Plan) error {
+	p.Step("Scaffolding Next.js app")
 
-	for _, dir := range dirs {
-		if err := utils.CreateDirectory(dir, 0o755); err != nil {
-			return fmt.Errorf("error creating directory %s: %v", dir, err)
-		}
-	}
+	// Build create command based on configuration
+	p.Run(g.buildCreateCommand(config.Frontend))
 
-	return nil
+	p.Step("Writing Next.js files")
+
+	// The create command makes the client directory; add our directories,
+	// components and environment files to it
+	return templates.PlanSet(ctx, p, "next", templates.NewData(ctx, config))
 }
 
-// createComponents creates the component files
-func (g *NextJSGenerator) createComponents() error {
-	files := map[string]func() string{
-		"src/components/homepage/Hero.tsx":         templates.HeroComponent,
-		"src/components/ui/navbar/Navbar.tsx":     templates.NavbarComponent,
-		"src/components/ui/texts/Typography.tsx":  templates.TypographyComponent,
-		"src/app/auth/signin/page.tsx":            templates.SignInPage,
-		"src/app/auth/callback/page.tsx":          templates.AuthCallbackPage,
+// buildCreateCommand builds the Next.js create command based on configuration.
+// create-next-app still asks about options without a flag, so every prompt is
+// answered with its default.
+func (g *NextJSGenerator) buildCreateCommand(frontend *types.FrontendConfig) runner.Command {
+	var cmd runner.Command
+	switch frontend.PackageManager {
+	case types.Npm:
+		cmd = runner.Command{Name: "npx", Args: []string{"create-next-app@latest", "client", "--use-npm"}}
+	case types.Pnpm:
+		cmd = runner.Command{Name: "pnpm", Args: []string{"create", "next-app@latest", "client", "--use-pnpm"}}
+	case types.Yarn:
+		cmd = runner.Command{Name: "yarn", Args: []string{"create", "next-app", "client", "--use-yarn"}}
+	default:
+		cmd = runner.Command{Name: "bun", Args: []string{"create", "next-app@latest", "client", "--use-bun"}}
 	}
 
-	for path, templateFunc := range files {
-		if err := utils.CreateFile(path, templateFunc()); err != nil {
-			return fmt.Errorf("error creating file %s: %v", path, err)
-		}
+	if frontend.TypeScript {
+		cmd.Args = append(cmd.Args, "--typescript")
+	} else {
+		cmd.Args = append(cmd.Args, "--js")
 	}
 
-	return nil
-}
-
-// createEnvFiles creates the environment files
-func (g *NextJSGenerator) createEnvFiles() error {
-	files := map[string]func() string{
-		".env":         templates.FrontendEnvFile,
-		".env.example": templates.FrontendEnvExampleFile,
+	if frontend.ESLint {
+		cmd.Args = append(cmd.Args, "--eslint")
+	} else {
+		cmd.Args = append(cmd.Args, "--no-eslint")
 	}
 
-	for path, templateFunc := range files {
-		if err := utils.CreateFile(path, templateFunc()); err != nil {
-			return fmt.Errorf("error creating file %s: %v", path, err)
-		}
+	if frontend.TailwindCSS {
+		cmd.Args = append(cmd.Args, "--tailwind")
+	} else {
+		cmd.Args = append(cmd.Args, "--no-tailwind")
 	}
 
-	return nil
-}
\ No newline at end of file
+	cmd.Args = append(cmd.Args, "--app") // Always use App Router
+	cmd.Env = []string{"NEXT_TELEMETRY_DISABLED=1"}
+	cmd.Timeout = createTimeout
+	cmd.Stdin = runner.Newlines
+	return cmd
+}
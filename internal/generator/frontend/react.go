@@ -1,12 +1,12 @@
 package frontend
 
 import (
-	"fmt"
-	"os"
-	"os/exec"
+	"context"
 
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/runner"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
 	"github.com/verse91/fsgo-dev-kit/internal/types"
-	"github.com/verse91/fsgo-dev-kit/pkg/utils"
 )
 
 // ReactGenerator handles React frontend generation
@@ -27,59 +27,59 @@ func (g *ReactGenerator) GetBuildCommands() []string {
 	return []string{"npm run build", "npm start"}
 }
 
-// Generate creates a new React frontend project
-func (g *ReactGenerator) Generate(config *types.ProjectConfig) error {
-	fmt.Println("🚀 Creating React frontend...")
-
-	// Build create command based on configuration
-	cmdArgs := g.buildCreateCommand(config.Frontend)
-	cmd := exec.Command("sh", "-c", cmdArgs)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("error creating React project: %v", err)
+// GetCapabilities returns the options supported by create-react-app
+func (g *ReactGenerator) GetCapabilities() types.FrontendCapabilities {
+	return types.FrontendCapabilities{
+		TypeScript:      true,
+		PackageManagers: []types.PackageManager{types.Npm, types.Yarn},
 	}
+}
 
-	// Change to client directory
-	if err := utils.ChangeDir("client"); err != nil {
-		return fmt.Errorf("error changing to client directory: %v", err)
+// Describe returns the React generator's descriptor
+func (g *ReactGenerator) Describe() types.GeneratorDescriptor {
+	capabilities := g.GetCapabilities()
+	return types.GeneratorDescriptor{
+		Name:            "react",
+		DisplayName:     string(g.GetFramework()),
+		Description:     "Single-page React app created with Create React App",
+		Homepage:        "https://react.dev",
+		RequiredTools:   []string{"node"},
+		Options:         capabilities.Options(),
+		PackageManagers: capabilities.PackageManagers,
+		Layout: []string{
+			"client/package.json",
+			"client/src/",
+			"client/public/",
+			"client/.env",
+		},
 	}
+}
 
-	// Create environment files
-	if err := g.createEnvFiles(); err != nil {
-		return fmt.Errorf("error creating environment files: %v", err)
-	}
+// Plan adds the actions creating a new React frontend project to p
+func (g *ReactGenerator) Plan(ctx context.Context, config *types.ProjectConfig, p *plan.Plan) error {
+	p.Step("Scaffolding React app")
 
-	// Go back to project root
-	if err := utils.ChangeDir(".."); err != nil {
-		return fmt.Errorf("error returning to project root: %v", err)
-	}
+	// Build create command based on configuration
+	p.Run(g.buildCreateCommand(config.Frontend))
 
-	return nil
+	p.Step("Writing React files")
+
+	// The create command makes the client directory; add the environment
+	// files to it
+	return templates.PlanSet(ctx, p, "react", templates.NewData(ctx, config))
 }
 
 // buildCreateCommand builds the React create command based on configuration
-func (g *ReactGenerator) buildCreateCommand(frontend *types.FrontendConfig) string {
-	baseCmd := "npx create-react-app client"
-	
-	if frontend.TypeScript {
-		baseCmd += " --template typescript"
+func (g *ReactGenerator) buildCreateCommand(frontend *types.FrontendConfig) runner.Command {
+	cmd := runner.Command{Name: "npx", Args: []string{"create-react-app", "client", "--use-npm"}}
+	if frontend.PackageManager == types.Yarn {
+		cmd = runner.Command{Name: "yarn", Args: []string{"create", "react-app", "client"}}
 	}
-	
-	return baseCmd
-}
-
-// createEnvFiles creates the environment files
-func (g *ReactGenerator) createEnvFiles() error {
-	envContent := "REACT_APP_API_URL=http://localhost:8080\n"
-	envExampleContent := "REACT_APP_API_URL=http://localhost:8080\n"
 
-	if err := utils.CreateFile(".env", envContent); err != nil {
-		return fmt.Errorf("error creating .env: %v", err)
-	}
-	if err := utils.CreateFile(".env.example", envExampleContent); err != nil {
-		return fmt.Errorf("error creating .env.example: %v", err)
+	if frontend.TypeScript {
+		cmd.Args = append(cmd.Args, "--template", "typescript")
 	}
 
-	return nil
-}
\ No newline at end of file
+	cmd.Timeout = createTimeout
+	return cmd
+}
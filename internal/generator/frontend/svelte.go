@@ -1,12 +1,12 @@
 package frontend
 
 import (
-	"fmt"
-	"os"
-	"os/exec"
+	"context"
 
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/runner"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
 	"github.com/verse91/fsgo-dev-kit/internal/types"
-	"github.com/verse91/fsgo-dev-kit/pkg/utils"
 )
 
 // SvelteGenerator handles Svelte frontend generation
@@ -27,57 +27,55 @@ func (g *SvelteGenerator) GetBuildCommands() []string {
 	return []string{"npm run build", "npm run dev"}
 }
 
-// Generate creates a new Svelte frontend project
-func (g *SvelteGenerator) Generate(config *types.ProjectConfig) error {
-	fmt.Println("🚀 Creating Svelte frontend...")
-
-	// Build create command based on configuration
-	cmdArgs := g.buildCreateCommand(config.Frontend)
-	cmd := exec.Command("sh", "-c", cmdArgs)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("error creating Svelte project: %v", err)
+// GetCapabilities returns the options supported by the Svelte generator.
+// create-svelte asks for its options interactively, so none are offered here.
+func (g *SvelteGenerator) GetCapabilities() types.FrontendCapabilities {
+	return types.FrontendCapabilities{
+		PackageManagers: []types.PackageManager{types.Npm},
 	}
+}
 
-	// Change to client directory
-	if err := utils.ChangeDir("client"); err != nil {
-		return fmt.Errorf("error changing to client directory: %v", err)
+// Describe returns the Svelte generator's descriptor
+func (g *SvelteGenerator) Describe() types.GeneratorDescriptor {
+	capabilities := g.GetCapabilities()
+	return types.GeneratorDescriptor{
+		Name:            "svelte",
+		DisplayName:     string(g.GetFramework()),
+		Description:     "Compiler-based reactive framework with a Vite dev server",
+		Homepage:        "https://svelte.dev",
+		RequiredTools:   []string{"node", "npm"},
+		Options:         capabilities.Options(),
+		PackageManagers: capabilities.PackageManagers,
+		Layout: []string{
+			"client/package.json",
+			"client/src/",
+			"client/static/",
+			"client/.env",
+		},
 	}
+}
 
-	// Create environment files
-	if err := g.createEnvFiles(); err != nil {
-		return fmt.Errorf("error creating environment files: %v", err)
-	}
+// Plan adds the actions creating a new Svelte frontend project to p
+func (g *SvelteGenerator) Plan(ctx context.Context, config *types.ProjectConfig, p *plan.Plan) error {
+	p.Step("Scaffolding Svelte app")
 
-	// Go back to project root
-	if err := utils.ChangeDir(".."); err != nil {
-		return fmt.Errorf("error returning to project root: %v", err)
-	}
+	// Build create command based on configuration
+	p.Run(g.buildCreateCommand(config.Frontend))
 
-	return nil
+	p.Step("Writing Svelte files")
+
+	// The create command makes the client directory; add the environment
+	// files to it
+	return templates.PlanSet(ctx, p, "svelte", templates.NewData(ctx, config))
 }
 
 // buildCreateCommand builds the Svelte create command based on configuration
-func (g *SvelteGenerator) buildCreateCommand(frontend *types.FrontendConfig) string {
-	baseCmd := "npm create svelte@latest client"
-	
+func (g *SvelteGenerator) buildCreateCommand(frontend *types.FrontendConfig) runner.Command {
 	// Svelte create process is interactive, so we'll use defaults
-	// In a real implementation, you might want to handle this differently
-	return baseCmd
-}
-
-// createEnvFiles creates the environment files
-func (g *SvelteGenerator) createEnvFiles() error {
-	envContent := "VITE_API_URL=http://localhost:8080\n"
-	envExampleContent := "VITE_API_URL=http://localhost:8080\n"
-
-	if err := utils.CreateFile(".env", envContent); err != nil {
-		return fmt.Errorf("error creating .env: %v", err)
+	return runner.Command{
+		Name:    "npm",
+		Args:    []string{"create", "svelte@latest", "client"},
+		Timeout: createTimeout,
+		Stdin:   runner.Newlines,
 	}
-	if err := utils.CreateFile(".env.example", envExampleContent); err != nil {
-		return fmt.Errorf("error creating .env.example: %v", err)
-	}
-
-	return nil
-}
\ No newline at end of file
+}
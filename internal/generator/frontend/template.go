@@ -0,0 +1,80 @@
+package frontend
+
+import (
+	"context"
+
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/runner"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+// TemplateGenerator generates a frontend that is defined entirely by its
+// descriptor and the template set named after it, such as a frontend of a
+// template pack. Instead of running a create command, the set writes the
+// whole client, including its package.json, and its dependencies are then
+// installed with the chosen package manager.
+type TemplateGenerator struct {
+	descriptor   types.GeneratorDescriptor
+	capabilities types.FrontendCapabilities
+}
+
+// NewTemplateGenerator creates a frontend generator described by descriptor
+// that renders the template set descriptor.Name and supports capabilities
+func NewTemplateGenerator(descriptor types.GeneratorDescriptor, capabilities types.FrontendCapabilities) *TemplateGenerator {
+	descriptor.Options = capabilities.Options()
+	descriptor.PackageManagers = capabilities.PackageManagers
+	return &TemplateGenerator{descriptor: descriptor, capabilities: capabilities}
+}
+
+// GetFramework returns the framework name, which is the display name of the
+// descriptor
+func (g *TemplateGenerator) GetFramework() types.FrontendFramework {
+	return types.FrontendFramework(g.descriptor.DisplayName)
+}
+
+// GetBuildCommands returns the build commands with the preferred package manager
+func (g *TemplateGenerator) GetBuildCommands() []string {
+	pm := string(g.packageManager(""))
+	return []string{pm + " run build", pm + " run start"}
+}
+
+// GetCapabilities returns the options the template set supports
+func (g *TemplateGenerator) GetCapabilities() types.FrontendCapabilities {
+	return g.capabilities
+}
+
+// Describe returns the generator's descriptor
+func (g *TemplateGenerator) Describe() types.GeneratorDescriptor {
+	return g.descriptor
+}
+
+// Plan adds the actions creating the frontend to p
+func (g *TemplateGenerator) Plan(ctx context.Context, config *types.ProjectConfig, p *plan.Plan) error {
+	p.Step("Writing " + g.descriptor.DisplayName + " files")
+	if err := templates.PlanSet(ctx, p, g.descriptor.Name, templates.NewData(ctx, config)); err != nil {
+		return err
+	}
+
+	p.Step("Installing frontend dependencies")
+	// Like the Go dependencies, a failed install is reported but doesn't stop
+	// the generation
+	p.Sub("client").RunOptional(runner.Command{
+		Name:    string(g.packageManager(config.Frontend.PackageManager)),
+		Args:    []string{"install"},
+		Timeout: createTimeout,
+	})
+	return nil
+}
+
+// packageManager returns chosen, or the preferred package manager when no
+// package manager was chosen
+func (g *TemplateGenerator) packageManager(chosen types.PackageManager) types.PackageManager {
+	if chosen != "" {
+		return chosen
+	}
+	if len(g.capabilities.PackageManagers) > 0 {
+		return g.capabilities.PackageManagers[0]
+	}
+	return types.Npm
+}
@@ -1,114 +1,239 @@
 package generator
 
 import (
+	"context"
 	"fmt"
+	"os/exec"
+	"path"
+	"slices"
+	"sort"
 
-	"github.com/verse91/fsgo-dev-kit/internal/prompt"
+	"github.com/verse91/fsgo-dev-kit/internal/docs"
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
 	"github.com/verse91/fsgo-dev-kit/internal/templates"
 	"github.com/verse91/fsgo-dev-kit/internal/types"
-	"github.com/verse91/fsgo-dev-kit/pkg/utils"
 )
 
 // ProjectGenerator handles the creation of fullstack projects
 type ProjectGenerator struct {
-	registry *GeneratorRegistry
-	prompter *prompt.ProjectPrompt
+	registry  *GeneratorRegistry
+	templates *templates.Library
 }
 
-// NewProjectGenerator creates a new project generator
+// NewProjectGenerator creates a new project generator with the built-in
+// generators and templates
 func NewProjectGenerator() *ProjectGenerator {
 	return &ProjectGenerator{
-		registry: NewGeneratorRegistry(),
-		prompter: prompt.NewProjectPrompt(),
+		registry:  NewGeneratorRegistry(),
+		templates: templates.Builtin(),
 	}
 }
 
-// Generate creates a complete fullstack project
-func (pg *ProjectGenerator) Generate() error {
-	// Get project configuration through interactive prompts
-	config, err := pg.prompter.GetProjectConfig()
-	if err != nil {
-		return fmt.Errorf("error getting project configuration: %v", err)
+// SetRegistry changes the generators Plan can use, e.g. to a registry
+// with the generators of a template pack
+func (pg *ProjectGenerator) SetRegistry(registry *GeneratorRegistry) {
+	pg.registry = registry
+}
+
+// SetTemplates changes where generators look up their template sets, e.g. to
+// a library with override directories
+func (pg *ProjectGenerator) SetTemplates(library *templates.Library) {
+	pg.templates = library
+}
+
+// Plan returns the actions generating config performs, in order. The same
+// plan is printed by a dry run and applied with a plan.Executor, so the two
+// cannot disagree.
+func (pg *ProjectGenerator) Plan(ctx context.Context, config *types.ProjectConfig) (*plan.Plan, error) {
+	if err := pg.registry.Validate(config); err != nil {
+		return nil, err
 	}
 
-	// Show configuration summary
-	pg.prompter.ShowSummary(config)
+	ctx = templates.NewContext(ctx, pg.templates)
+	p := plan.New()
 
-	// Change to project path if not current directory
-	if config.Path != "." {
-		if err := utils.CreateDirectory(config.Path, 0o755); err != nil {
-			return fmt.Errorf("error creating project directory: %v", err)
-		}
-		if err := utils.ChangeDir(config.Path); err != nil {
-			return fmt.Errorf("error changing to project directory: %v", err)
-		}
-		defer func() {
-			_ = utils.ChangeDir("..") // Go back to original directory
-		}()
+	// Create the project directory if it doesn't exist yet
+	p.Mkdir(".", plan.DirMode)
+
+	// Generate backend and frontend (only for web projects)
+	if err := pg.registry.planFrameworks(ctx, config, p); err != nil {
+		return nil, err
 	}
 
-	// Generate backend
-	if err := pg.generateBackend(config); err != nil {
-		return fmt.Errorf("error generating backend: %v", err)
+	// Create root files and the docs for the chosen stack
+	if err := pg.registry.planRootFiles(ctx, config, p); err != nil {
+		return nil, err
 	}
 
-	// Generate frontend (only for web projects)
-	if config.Type == types.WebProject {
-		if err := pg.generateFrontend(config); err != nil {
-			return fmt.Errorf("error generating frontend: %v", err)
+	return p, nil
+}
+
+// CheckTools returns a types.MissingToolError listing the executables that
+// generating config with p needs and that are not on PATH: the tools its
+// generators require and the programs p runs
+func (pg *ProjectGenerator) CheckTools(config *types.ProjectConfig, p *plan.Plan) error {
+	var tools []string
+	if gen, exists := pg.registry.GetBackendGenerator(config.BackendFramework); exists {
+		tools = append(tools, gen.Describe().RequiredTools...)
+	}
+	if config.Type == types.WebProject && config.Frontend != nil {
+		if gen, exists := pg.registry.GetFrontendGenerator(config.Frontend.Framework); exists {
+			tools = append(tools, gen.Describe().RequiredTools...)
 		}
 	}
+	tools = append(tools, p.Tools()...)
 
-	// Create root files
-	if err := pg.createRootFiles(config); err != nil {
-		return fmt.Errorf("error creating root files: %v", err)
+	var missing []string
+	for _, tool := range tools {
+		if slices.Contains(missing, tool) {
+			continue
+		}
+		if _, err := exec.LookPath(tool); err != nil {
+			missing = append(missing, tool)
+		}
+	}
+	if len(missing) > 0 {
+		return &types.MissingToolError{Tools: missing}
 	}
+	return nil
+}
 
-	fmt.Printf("✅ Project %s created successfully!\n", config.Name)
-	fmt.Println("\nNext steps:")
+// NextSteps returns the commands that run the project generated from config,
+// each with a comment saying what it does
+func NextSteps(config *types.ProjectConfig) []string {
 	if config.Type == types.WebProject {
-		fmt.Println("  make run  # Start both frontend and backend")
-	} else {
-		fmt.Println("  make b    # Start backend server")
+		return []string{"make run  # Start both frontend and backend"}
 	}
+	return []string{"make b    # Start backend server"}
+}
 
+// planFrameworks adds the actions of the backend generator and, for web
+// projects, the frontend generator to p, each in its own group so they are
+// applied concurrently. Unregistered frameworks are skipped; Plan validates
+// config before calling it.
+func (r *GeneratorRegistry) planFrameworks(ctx context.Context, config *types.ProjectConfig, p *plan.Plan) error {
+	if gen, exists := r.GetBackendGenerator(config.BackendFramework); exists {
+		if err := gen.Plan(ctx, config, p.Group("backend")); err != nil {
+			return fmt.Errorf("error generating backend: %w", err)
+		}
+	}
+	if config.Type == types.WebProject && config.Frontend != nil {
+		if gen, exists := r.GetFrontendGenerator(config.Frontend.Framework); exists {
+			if err := gen.Plan(ctx, config, p.Group("frontend")); err != nil {
+				return fmt.Errorf("error generating frontend: %w", err)
+			}
+		}
+	}
 	return nil
 }
 
-// generateBackend generates the backend using the appropriate generator
-func (pg *ProjectGenerator) generateBackend(config *types.ProjectConfig) error {
-	generator, exists := pg.registry.GetBackendGenerator(config.BackendFramework)
-	if !exists {
-		return fmt.Errorf("backend generator not found for framework: %s", config.BackendFramework)
+// planRootFiles adds the project root files to p, along with the embedded
+// documentation for the project layout and the chosen frameworks in docs/.
+// Frameworks without embedded docs are skipped.
+func (r *GeneratorRegistry) planRootFiles(ctx context.Context, config *types.ProjectConfig, p *plan.Plan) error {
+	p.Step("Writing root files")
+	data := templates.NewData(ctx, config)
+	if err := templates.PlanSet(ctx, p, "root", data); err != nil {
+		return err
 	}
-	return generator.Generate(config)
+
+	for _, topic := range r.docTopics(config) {
+		markdown, err := docs.Markdown(topic)
+		if err != nil {
+			continue
+		}
+		name := path.Join("docs", topic+".md")
+		content, err := templates.Render(name, string(markdown), data)
+		if err != nil {
+			return err
+		}
+		p.WriteFile(name, content, plan.FileMode)
+	}
+	return nil
 }
 
-// generateFrontend generates the frontend using the appropriate generator
-func (pg *ProjectGenerator) generateFrontend(config *types.ProjectConfig) error {
-	if config.Frontend == nil {
-		return fmt.Errorf("frontend configuration is nil")
+// Doc returns a documentation topic as it is written into a project generated
+// with the default configuration, or with the framework the topic documents
+func (r *GeneratorRegistry) Doc(ctx context.Context, topic string) (string, error) {
+	markdown, err := docs.Markdown(topic)
+	if err != nil {
+		return "", err
+	}
+
+	config := types.DefaultProjectConfig()
+	config.Name = "my-app"
+	for _, framework := range r.GetAvailableBackendFrameworks() {
+		if gen, _ := r.GetBackendGenerator(framework); gen.Describe().Name == topic {
+			config.BackendFramework = framework
+		}
+	}
+	for _, framework := range r.GetAvailableFrontendFrameworks() {
+		if gen, _ := r.GetFrontendGenerator(framework); gen.Describe().Name == topic {
+			config.Frontend.Framework = framework
+		}
 	}
-	generator, exists := pg.registry.GetFrontendGenerator(config.Frontend.Framework)
-	if !exists {
-		return fmt.Errorf("frontend generator not found for framework: %s", config.Frontend.Framework)
+	if capabilities, exists := r.GetFrontendCapabilities(config.Frontend.Framework); exists {
+		if err := capabilities.Restrict(config.Frontend, types.FieldSet{}); err != nil {
+			return "", err
+		}
 	}
-	return generator.Generate(config)
+
+	return templates.Render(path.Join("docs", topic+".md"), string(markdown), templates.NewData(ctx, config))
 }
 
-// createRootFiles creates the project root files
-func (pg *ProjectGenerator) createRootFiles(config *types.ProjectConfig) error {
-	files := map[string]func() string{
-		".gitignore": templates.GitignoreTemplate,
-		"Makefile":   templates.MakefileTemplate,
-		"README.md":  templates.ReadmeTemplate,
+// docTopics returns the documentation topics that apply to config
+func (r *GeneratorRegistry) docTopics(config *types.ProjectConfig) []string {
+	topics := []string{docs.ProjectTopic}
+	if gen, exists := r.GetBackendGenerator(config.BackendFramework); exists {
+		topics = append(topics, gen.Describe().Name)
 	}
+	if config.Type == types.WebProject && config.Frontend != nil {
+		if gen, exists := r.GetFrontendGenerator(config.Frontend.Framework); exists {
+			topics = append(topics, gen.Describe().Name)
+		}
+	}
+	return topics
+}
 
-	for path, templateFunc := range files {
-		if err := utils.CreateFile(path, templateFunc()); err != nil {
-			return fmt.Errorf("error creating file %s: %v", path, err)
+// Preview returns the files and commands generating config would produce.
+// Unregistered frameworks are left out, so partial configurations can be
+// previewed while they are being edited. Generators are summarized by their
+// layout, which includes files created by their commands, and everything
+// else is taken from the plan. Template sets are looked up in the library ctx
+// carries, as for ProjectGenerator.Plan.
+func (r *GeneratorRegistry) Preview(ctx context.Context, config *types.ProjectConfig) types.Preview {
+	var preview types.Preview
+
+	if gen, exists := r.GetBackendGenerator(config.BackendFramework); exists {
+		preview.Files = append(preview.Files, gen.Describe().Layout...)
+	}
+	if config.Type == types.WebProject && config.Frontend != nil {
+		if gen, exists := r.GetFrontendGenerator(config.Frontend.Framework); exists {
+			preview.Files = append(preview.Files, gen.Describe().Layout...)
 		}
 	}
 
-	return nil
-}
\ No newline at end of file
+	frameworks := plan.New()
+	if err := r.planFrameworks(ctx, config, frameworks); err == nil {
+		for _, action := range frameworks.Actions() {
+			if action.Kind != plan.KindRun {
+				continue
+			}
+			command := action.CommandLine()
+			if action.Dir != "." {
+				command = "(" + action.Dir + ") " + command
+			}
+			preview.Commands = append(preview.Commands, command)
+		}
+	}
+
+	rootFiles := plan.New()
+	if err := r.planRootFiles(ctx, config, rootFiles); err == nil {
+		for _, action := range rootFiles.Actions() {
+			preview.Files = append(preview.Files, action.Path)
+		}
+	}
+	sort.Strings(preview.Files)
+
+	return preview
+}
@@ -0,0 +1,291 @@
+package generator_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/verse91/fsgo-dev-kit/internal/events"
+	"github.com/verse91/fsgo-dev-kit/internal/generator"
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/runner"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/pkg/fsys"
+)
+
+// generate plans config and applies the plan to a temporary directory with
+// a fake runner, returning the commands that were run as "<dir>: <command>"
+func generate(t *testing.T, config *types.ProjectConfig, executor *plan.Executor) ([]string, error) {
+	t.Helper()
+	p, err := generator.NewProjectGenerator().Plan(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+
+	dir := t.TempDir()
+	root, err := fsys.NewOS(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake, ok := executor.Runner.(*runner.Fake)
+	if !ok {
+		fake = runner.NewFake()
+		executor.Runner = fake
+	}
+	executor.Events = events.NewJSON(io.Discard)
+	err = executor.Apply(context.Background(), p, root)
+
+	var commands []string
+	for _, cmd := range fake.Commands() {
+		rel, relErr := filepath.Rel(dir, cmd.Dir)
+		if relErr != nil {
+			t.Fatal(relErr)
+		}
+		commands = append(commands, filepath.ToSlash(rel)+": "+strings.Join(append([]string{cmd.Name}, cmd.Args...), " "))
+	}
+	return commands, err
+}
+
+// withDir filters the commands returned by generate to those run in dir
+func withDir(commands []string, dir string) []string {
+	var filtered []string
+	for _, command := range commands {
+		if strings.HasPrefix(command, dir+": ") {
+			filtered = append(filtered, command)
+		}
+	}
+	return filtered
+}
+
+func TestBackendCommands(t *testing.T) {
+	tests := []struct {
+		framework types.BackendFramework
+		deps      []string
+	}{
+		{types.Fiber, []string{
+			"github.com/gofiber/fiber/v3",
+			"github.com/joho/godotenv",
+			"go.uber.org/zap",
+			"github.com/gofiber/helmet/v2",
+			"github.com/gofiber/cors",
+		}},
+		{types.Gin, []string{
+			"github.com/gin-gonic/gin",
+			"github.com/joho/godotenv",
+			"go.uber.org/zap",
+			"github.com/gin-contrib/cors",
+		}},
+		{types.Echo, []string{
+			"github.com/labstack/echo/v4",
+			"github.com/labstack/echo/v4/middleware",
+			"github.com/joho/godotenv",
+			"go.uber.org/zap",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.framework), func(t *testing.T) {
+			config := &types.ProjectConfig{
+				Name:             "shop",
+				Path:             "shop",
+				Type:             types.APIProject,
+				BackendFramework: tt.framework,
+				ModulePath:       "github.com/acme/shop/server",
+			}
+			commands, err := generate(t, config, &plan.Executor{})
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+
+			want := []string{"server: go mod init github.com/acme/shop/server"}
+			for _, dep := range tt.deps {
+				want = append(want, "server: go get "+dep)
+			}
+			if !slices.Equal(commands, want) {
+				t.Errorf("commands:\n got %q\nwant %q", commands, want)
+			}
+		})
+	}
+}
+
+func TestDefaultModulePath(t *testing.T) {
+	config := &types.ProjectConfig{Name: "shop", Path: "shop", Type: types.APIProject, BackendFramework: types.Gin}
+	commands, err := generate(t, config, &plan.Executor{})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if len(commands) == 0 || commands[0] != "server: go mod init shop/server" {
+		t.Errorf("first command = %q, want go mod init shop/server", commands)
+	}
+}
+
+func TestFrontendCreateCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		frontend types.FrontendConfig
+		want     string
+	}{
+		{
+			"next with bun",
+			types.FrontendConfig{Framework: types.NextJS, TypeScript: true, TailwindCSS: true, ESLint: true, PackageManager: types.Bun},
+			"bun create next-app@latest client --use-bun --typescript --eslint --tailwind --app",
+		},
+		{
+			"next with npm",
+			types.FrontendConfig{Framework: types.NextJS, PackageManager: types.Npm},
+			"npx create-next-app@latest client --use-npm --js --no-eslint --no-tailwind --app",
+		},
+		{
+			"next with pnpm",
+			types.FrontendConfig{Framework: types.NextJS, TypeScript: true, PackageManager: types.Pnpm},
+			"pnpm create next-app@latest client --use-pnpm --typescript --no-eslint --no-tailwind --app",
+		},
+		{
+			"next with yarn",
+			types.FrontendConfig{Framework: types.NextJS, TailwindCSS: true, PackageManager: types.Yarn},
+			"yarn create next-app client --use-yarn --js --no-eslint --tailwind --app",
+		},
+		{
+			"react with npm",
+			types.FrontendConfig{Framework: types.React, TypeScript: true, PackageManager: types.Npm},
+			"npx create-react-app client --use-npm --template typescript",
+		},
+		{
+			"react with yarn",
+			types.FrontendConfig{Framework: types.React, PackageManager: types.Yarn},
+			"yarn create react-app client",
+		},
+		{
+			"svelte",
+			types.FrontendConfig{Framework: types.Svelte, PackageManager: types.Npm},
+			"npm create svelte@latest client",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontend := tt.frontend
+			config := &types.ProjectConfig{
+				Name:             "shop",
+				Path:             "shop",
+				Type:             types.WebProject,
+				BackendFramework: types.Fiber,
+				Frontend:         &frontend,
+			}
+			commands, err := generate(t, config, &plan.Executor{})
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+
+			want := []string{".: " + tt.want}
+			if got := withDir(commands, "."); !slices.Equal(got, want) {
+				t.Errorf("frontend commands:\n got %q\nwant %q", got, want)
+			}
+			if got := withDir(commands, "server"); len(got) == 0 {
+				t.Error("backend commands were not run")
+			}
+		})
+	}
+}
+
+func TestOptionalCommandFailure(t *testing.T) {
+	config := &types.ProjectConfig{Name: "shop", Path: "shop", Type: types.APIProject, BackendFramework: types.Echo}
+	failGet := func() *runner.Fake {
+		fake := runner.NewFake()
+		fake.Handle = func(ctx context.Context, cmd runner.Command) error {
+			if cmd.Name == "go" && cmd.Args[0] == "get" {
+				return errors.New("network unreachable")
+			}
+			return nil
+		}
+		return fake
+	}
+
+	executor := &plan.Executor{Runner: failGet()}
+	commands, err := generate(t, config, executor)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got, want := len(executor.Warnings()), len(commands)-1; got != want {
+		t.Errorf("got %d warnings, want one per go get (%d)", got, want)
+	}
+
+	_, err = generate(t, config, &plan.Executor{Runner: failGet(), Strict: true})
+	if !errors.Is(err, types.ErrDependencyInstall) {
+		t.Errorf("strict Apply error = %v, want ErrDependencyInstall", err)
+	}
+}
+
+func TestDocsMatchProject(t *testing.T) {
+	config := &types.ProjectConfig{
+		Name:             "shop",
+		Path:             "shop",
+		Type:             types.WebProject,
+		BackendFramework: types.Gin,
+		Frontend:         &types.FrontendConfig{Framework: types.NextJS, TypeScript: true, PackageManager: types.Pnpm},
+	}
+	p, err := generator.NewProjectGenerator().Plan(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	docs := map[string]string{}
+	for _, action := range p.Actions() {
+		if action.Kind == plan.KindWrite && strings.HasPrefix(action.Path, "docs/") {
+			docs[action.Path] = string(action.Content)
+		}
+	}
+
+	tests := []struct {
+		path    string
+		want    []string
+		notWant []string
+	}{
+		{"docs/project.md", []string{"`pnpm run dev`", "`go run .`", "client/"}, []string{"air", "testdb"}},
+		{"docs/gin.md", []string{"`8080`"}, nil},
+		{"docs/next.md", []string{"pnpm run build", "`http://localhost:8080`"}, []string{"bun", "lint", "{{"}},
+	}
+	for _, tt := range tests {
+		content, ok := docs[tt.path]
+		if !ok {
+			t.Errorf("%s is not written; docs: %q", tt.path, slices.Sorted(maps.Keys(docs)))
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s does not contain %q:\n%s", tt.path, want, content)
+			}
+		}
+		for _, notWant := range tt.notWant {
+			if strings.Contains(content, notWant) {
+				t.Errorf("%s contains %q:\n%s", tt.path, notWant, content)
+			}
+		}
+	}
+}
+
+func TestPreviewUsesLibrary(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "root"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "root", "CONTRIBUTING.md.tmpl"), []byte("# {{.AppName}}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &types.ProjectConfig{Name: "shop", Path: "shop", Type: types.APIProject, BackendFramework: types.Gin}
+	registry := generator.NewGeneratorRegistry()
+	if files := registry.Preview(context.Background(), config).Files; slices.Contains(files, "CONTRIBUTING.md") {
+		t.Errorf("built-in preview lists CONTRIBUTING.md: %q", files)
+	}
+	ctx := templates.NewContext(context.Background(), templates.NewLibrary(dir))
+	if files := registry.Preview(ctx, config).Files; !slices.Contains(files, "CONTRIBUTING.md") {
+		t.Errorf("preview with overrides does not list CONTRIBUTING.md: %q", files)
+	}
+}
@@ -1,58 +1,79 @@
 package generator
 
 import (
+	"context"
+
 	"github.com/verse91/fsgo-dev-kit/internal/generator/backend"
 	"github.com/verse91/fsgo-dev-kit/internal/generator/frontend"
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
 	"github.com/verse91/fsgo-dev-kit/internal/types"
 )
 
-// BackendGenerator interface for backend framework generators
+// BackendGenerator interface for backend framework generators. Plan adds
+// the files, directories and commands of the backend to p, which is rooted
+// at the project directory; generators never write to disk themselves.
 type BackendGenerator interface {
-	Generate(config *types.ProjectConfig) error
+	Plan(ctx context.Context, config *types.ProjectConfig, p *plan.Plan) error
 	GetFramework() types.BackendFramework
 	GetDependencies() []string
+	Describe() types.GeneratorDescriptor
 }
 
-// FrontendGenerator interface for frontend framework generators
+// FrontendGenerator interface for frontend framework generators. Plan adds
+// the files, directories and commands of the frontend to p, which is rooted
+// at the project directory; generators never write to disk themselves.
 type FrontendGenerator interface {
-	Generate(config *types.ProjectConfig) error
+	Plan(ctx context.Context, config *types.ProjectConfig, p *plan.Plan) error
 	GetFramework() types.FrontendFramework
 	GetBuildCommands() []string
+	GetCapabilities() types.FrontendCapabilities
+	Describe() types.GeneratorDescriptor
 }
 
 // GeneratorRegistry manages available generators
 type GeneratorRegistry struct {
 	backendGenerators  map[types.BackendFramework]BackendGenerator
 	frontendGenerators map[types.FrontendFramework]FrontendGenerator
+	backendOrder       []types.BackendFramework
+	frontendOrder      []types.FrontendFramework
 }
 
-// NewGeneratorRegistry creates a new registry with all available generators
+// NewGeneratorRegistry creates a new registry with all available generators.
+// Generators are offered in the order they are registered here.
 func NewGeneratorRegistry() *GeneratorRegistry {
 	registry := &GeneratorRegistry{
 		backendGenerators:  make(map[types.BackendFramework]BackendGenerator),
 		frontendGenerators: make(map[types.FrontendFramework]FrontendGenerator),
 	}
-	
+
 	// Register backend generators
 	registry.RegisterBackendGenerator(backend.NewFiberGenerator())
 	registry.RegisterBackendGenerator(backend.NewGinGenerator())
 	registry.RegisterBackendGenerator(backend.NewEchoGenerator())
-	
+
 	// Register frontend generators
 	registry.RegisterFrontendGenerator(frontend.NewNextJSGenerator())
 	registry.RegisterFrontendGenerator(frontend.NewReactGenerator())
 	registry.RegisterFrontendGenerator(frontend.NewSvelteGenerator())
-	
+
 	return registry
 }
 
-// RegisterBackendGenerator registers a backend generator
+// RegisterBackendGenerator registers a backend generator, replacing any
+// generator already registered for the same framework in place
 func (r *GeneratorRegistry) RegisterBackendGenerator(gen BackendGenerator) {
+	if _, exists := r.backendGenerators[gen.GetFramework()]; !exists {
+		r.backendOrder = append(r.backendOrder, gen.GetFramework())
+	}
 	r.backendGenerators[gen.GetFramework()] = gen
 }
 
-// RegisterFrontendGenerator registers a frontend generator
+// RegisterFrontendGenerator registers a frontend generator, replacing any
+// generator already registered for the same framework in place
 func (r *GeneratorRegistry) RegisterFrontendGenerator(gen FrontendGenerator) {
+	if _, exists := r.frontendGenerators[gen.GetFramework()]; !exists {
+		r.frontendOrder = append(r.frontendOrder, gen.GetFramework())
+	}
 	r.frontendGenerators[gen.GetFramework()] = gen
 }
 
@@ -68,20 +89,91 @@ func (r *GeneratorRegistry) GetFrontendGenerator(framework types.FrontendFramewo
 	return gen, exists
 }
 
-// GetAvailableBackendFrameworks returns list of registered backend frameworks
+// GetAvailableBackendFrameworks returns registered backend frameworks in registration order
 func (r *GeneratorRegistry) GetAvailableBackendFrameworks() []types.BackendFramework {
-	frameworks := make([]types.BackendFramework, 0, len(r.backendGenerators))
-	for framework := range r.backendGenerators {
-		frameworks = append(frameworks, framework)
-	}
-	return frameworks
+	return append([]types.BackendFramework(nil), r.backendOrder...)
 }
 
-// GetAvailableFrontendFrameworks returns list of registered frontend frameworks
+// GetAvailableFrontendFrameworks returns registered frontend frameworks in registration order
 func (r *GeneratorRegistry) GetAvailableFrontendFrameworks() []types.FrontendFramework {
-	frameworks := make([]types.FrontendFramework, 0, len(r.frontendGenerators))
-	for framework := range r.frontendGenerators {
-		frameworks = append(frameworks, framework)
+	return append([]types.FrontendFramework(nil), r.frontendOrder...)
+}
+
+// ParseBackendFramework returns the backend framework named s: the name or
+// display name of a registered generator, ignoring case and punctuation, or
+// else a framework types.ParseBackendFramework knows, registered or not
+func (r *GeneratorRegistry) ParseBackendFramework(s string) (types.BackendFramework, error) {
+	for _, framework := range r.backendOrder {
+		d := r.backendGenerators[framework].Describe()
+		if types.SameName(s, d.Name) || types.SameName(s, d.DisplayName) {
+			return framework, nil
+		}
+	}
+	return types.ParseBackendFramework(s)
+}
+
+// ParseFrontendFramework returns the frontend framework named s, like
+// ParseBackendFramework
+func (r *GeneratorRegistry) ParseFrontendFramework(s string) (types.FrontendFramework, error) {
+	for _, framework := range r.frontendOrder {
+		d := r.frontendGenerators[framework].Describe()
+		if types.SameName(s, d.Name) || types.SameName(s, d.DisplayName) {
+			return framework, nil
+		}
+	}
+	return types.ParseFrontendFramework(s)
+}
+
+// GetFrontendCapabilities returns the options supported by a frontend generator
+func (r *GeneratorRegistry) GetFrontendCapabilities(framework types.FrontendFramework) (types.FrontendCapabilities, bool) {
+	gen, exists := r.frontendGenerators[framework]
+	if !exists {
+		return types.FrontendCapabilities{}, false
 	}
-	return frameworks
-}
\ No newline at end of file
+	return gen.GetCapabilities(), true
+}
+
+// DescribeBackends returns the descriptors of all backend generators in registration order
+func (r *GeneratorRegistry) DescribeBackends() []types.GeneratorDescriptor {
+	descriptors := make([]types.GeneratorDescriptor, len(r.backendOrder))
+	for i, framework := range r.backendOrder {
+		descriptors[i] = r.backendGenerators[framework].Describe()
+	}
+	return descriptors
+}
+
+// DescribeFrontends returns the descriptors of all frontend generators in registration order
+func (r *GeneratorRegistry) DescribeFrontends() []types.GeneratorDescriptor {
+	descriptors := make([]types.GeneratorDescriptor, len(r.frontendOrder))
+	for i, framework := range r.frontendOrder {
+		descriptors[i] = r.frontendGenerators[framework].Describe()
+	}
+	return descriptors
+}
+
+// Validate checks that config only uses registered generators and options
+// those generators support
+func (r *GeneratorRegistry) Validate(config *types.ProjectConfig) error {
+	if _, exists := r.backendGenerators[config.BackendFramework]; !exists {
+		return &types.UnknownFrameworkError{Kind: "backend", Name: string(config.BackendFramework)}
+	}
+	if config.Type != types.WebProject {
+		return nil
+	}
+	if config.Frontend == nil {
+		return &types.ConfigError{Message: "frontend configuration is missing"}
+	}
+
+	capabilities, exists := r.GetFrontendCapabilities(config.Frontend.Framework)
+	if !exists {
+		return &types.UnknownFrameworkError{Kind: "frontend", Name: string(config.Frontend.Framework)}
+	}
+	explicit := types.FieldSet{
+		types.FieldTypeScript:     true,
+		types.FieldTailwindCSS:    true,
+		types.FieldESLint:         true,
+		types.FieldPackageManager: config.Frontend.PackageManager != "",
+	}
+	frontend := *config.Frontend
+	return capabilities.Restrict(&frontend, explicit)
+}
@@ -0,0 +1,273 @@
+package pack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/verse91/fsgo-dev-kit/internal/generator"
+	"github.com/verse91/fsgo-dev-kit/internal/generator/backend"
+	"github.com/verse91/fsgo-dev-kit/internal/generator/frontend"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+// ManifestName is the name of the manifest at the top of a pack
+const ManifestName = "fsgo-pack.yaml"
+
+// TemplatesDir is the directory of a pack holding its template sets
+const TemplatesDir = "templates"
+
+// Development settings of pack frontends that don't set them
+const (
+	defaultFrontendPort = 3000
+	defaultDevScript    = "dev"
+)
+
+// generatorNamePattern restricts generator names to ones that are also valid
+// template set names and flag values
+var generatorNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// Pack is a template pack: a manifest and a directory of template sets laid
+// out like an override directory. A set named after a built-in one, such as
+// templates/fiber, adds files to that generator or replaces some of its
+// templates. The manifest can also define new generators, each rendering the
+// set of its name.
+type Pack struct {
+	// Source is where the pack was read from
+	Source Source
+	// Dir is the local directory of the pack, a clone in the cache for git
+	// sources
+	Dir      string
+	Manifest Manifest
+}
+
+// Manifest describes a pack. It is read from the pack's fsgo-pack.yaml.
+type Manifest struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// Backends are the backend generators the pack defines
+	Backends []Generator `yaml:"backends"`
+	// Frontends are the frontend generators the pack defines
+	Frontends []Generator `yaml:"frontends"`
+}
+
+// Generator defines a generator of a pack
+type Generator struct {
+	// Name is the value of --backend or --frontend and the template set the
+	// generator renders
+	Name string `yaml:"name"`
+	// DisplayName is shown in prompts and summaries; it defaults to Name
+	DisplayName   string   `yaml:"display-name"`
+	Description   string   `yaml:"description"`
+	Homepage      string   `yaml:"homepage"`
+	RequiredTools []string `yaml:"required-tools"`
+	// Layout lists the main directories and files produced for previews; it
+	// defaults to server/ or client/
+	Layout []string `yaml:"layout"`
+
+	// Dependencies are the Go modules installed into a backend
+	Dependencies []string `yaml:"dependencies"`
+
+	// Options are the frontend options the templates support: typescript,
+	// tailwind and eslint
+	Options []types.ConfigField `yaml:"options"`
+	// PackageManagers lists the package managers a frontend supports,
+	// preferred first; it defaults to npm
+	PackageManagers []types.PackageManager `yaml:"package-managers"`
+	// Port, EnvPrefix and DevScript are the development settings of a
+	// frontend the root templates use, like those of the built-in ones. They
+	// default to 3000, no prefix and dev.
+	Port      int    `yaml:"port"`
+	EnvPrefix string `yaml:"env-prefix"`
+	DevScript string `yaml:"dev-script"`
+}
+
+// Open fetches the pack at source, cloning git repositories into cacheDir,
+// and loads it
+func Open(ctx context.Context, source Source, cacheDir string) (*Pack, error) {
+	dir, err := source.Fetch(ctx, cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	p, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	p.Source = source
+	return p, nil
+}
+
+// Load reads the pack in dir
+func Load(dir string) (*Pack, error) {
+	p := &Pack{Source: Source{Dir: dir}, Dir: dir}
+
+	file, err := os.Open(filepath.Join(dir, ManifestName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &types.ConfigError{Message: fmt.Sprintf("%s is not a template pack: it has no %s", dir, ManifestName)}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading template pack: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&p.Manifest); err != nil && err != io.EOF {
+		return nil, &types.ConfigError{Message: fmt.Sprintf("%s: %v", filepath.Join(dir, ManifestName), err)}
+	}
+
+	if info, err := os.Stat(p.TemplatesPath()); err != nil || !info.IsDir() {
+		return nil, p.errorf("it has no %s directory", TemplatesDir)
+	}
+	return p, nil
+}
+
+// Name returns the name of the pack from its manifest, or else its source
+func (p *Pack) Name() string {
+	if p.Manifest.Name != "" {
+		return p.Manifest.Name
+	}
+	return p.Source.String()
+}
+
+// TemplatesPath returns the directory of the pack's template sets, to be
+// added to a templates.Library
+func (p *Pack) TemplatesPath() string {
+	return filepath.Join(p.Dir, TemplatesDir)
+}
+
+// Register adds the generators defined by the pack to registry and the
+// development settings of its frontends to library, the library of the
+// generation that uses them. Their names must not be taken by a registered
+// generator or a built-in template set, and the pack must have a template set
+// for each of them.
+func (p *Pack) Register(registry *generator.GeneratorRegistry, library *templates.Library) error {
+	taken := templates.Builtin().Sets()
+	for _, d := range append(registry.DescribeBackends(), registry.DescribeFrontends()...) {
+		taken = append(taken, d.Name, d.DisplayName)
+	}
+
+	for _, gen := range p.Manifest.Backends {
+		descriptor, err := p.descriptor("backend", gen, taken)
+		if err != nil {
+			return err
+		}
+		if len(gen.Options) > 0 || len(gen.PackageManagers) > 0 {
+			return p.errorf("backend %s: options and package managers only apply to frontends", gen.Name)
+		}
+		if descriptor.RequiredTools == nil {
+			descriptor.RequiredTools = []string{"go"}
+		}
+		if descriptor.Layout == nil {
+			descriptor.Layout = []string{"server/go.mod", "server/"}
+		}
+		descriptor.Dependencies = gen.Dependencies
+		registry.RegisterBackendGenerator(backend.NewTemplateGenerator(descriptor))
+		taken = append(taken, descriptor.Name, descriptor.DisplayName)
+	}
+
+	for _, gen := range p.Manifest.Frontends {
+		descriptor, err := p.descriptor("frontend", gen, taken)
+		if err != nil {
+			return err
+		}
+		if len(gen.Dependencies) > 0 {
+			return p.errorf("frontend %s: dependencies only apply to backends", gen.Name)
+		}
+		capabilities, err := p.capabilities(gen)
+		if err != nil {
+			return err
+		}
+		if descriptor.RequiredTools == nil {
+			descriptor.RequiredTools = []string{"node"}
+		}
+		if descriptor.Layout == nil {
+			descriptor.Layout = []string{"client/package.json", "client/"}
+		}
+		settings := templates.FrontendData{Port: gen.Port, EnvPrefix: gen.EnvPrefix, DevScript: gen.DevScript}
+		if settings.Port == 0 {
+			settings.Port = defaultFrontendPort
+		}
+		if settings.DevScript == "" {
+			settings.DevScript = defaultDevScript
+		}
+		frontendGen := frontend.NewTemplateGenerator(descriptor, capabilities)
+		registry.RegisterFrontendGenerator(frontendGen)
+		library.RegisterFrontend(frontendGen.GetFramework(), settings)
+		taken = append(taken, descriptor.Name, descriptor.DisplayName)
+	}
+	return nil
+}
+
+// descriptor checks the fields gen has for either kind and returns its
+// descriptor
+func (p *Pack) descriptor(kind string, gen Generator, taken []string) (types.GeneratorDescriptor, error) {
+	if !generatorNamePattern.MatchString(gen.Name) {
+		return types.GeneratorDescriptor{}, p.errorf("invalid %s name %q: use lowercase letters, digits, '.', '_' and '-'", kind, gen.Name)
+	}
+	displayName := gen.DisplayName
+	if displayName == "" {
+		displayName = gen.Name
+	}
+	for _, name := range []string{gen.Name, displayName} {
+		if slices.ContainsFunc(taken, func(t string) bool { return types.SameName(t, name) }) {
+			return types.GeneratorDescriptor{}, p.errorf("%s %s is already defined; to add files to a built-in generator, put them in %s/<its name> instead", kind, name, TemplatesDir)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(p.TemplatesPath(), gen.Name)); err != nil || !info.IsDir() {
+		return types.GeneratorDescriptor{}, p.errorf("%s %s has no template set %s/%s", kind, gen.Name, TemplatesDir, gen.Name)
+	}
+
+	return types.GeneratorDescriptor{
+		Name:          gen.Name,
+		DisplayName:   displayName,
+		Description:   gen.Description,
+		Homepage:      gen.Homepage,
+		RequiredTools: gen.RequiredTools,
+		Options:       []types.ConfigField{},
+		Layout:        gen.Layout,
+	}, nil
+}
+
+// capabilities returns the options and package managers of the frontend gen
+func (p *Pack) capabilities(gen Generator) (types.FrontendCapabilities, error) {
+	capabilities := types.FrontendCapabilities{PackageManagers: []types.PackageManager{types.Npm}}
+	for _, option := range gen.Options {
+		switch option {
+		case types.FieldTypeScript:
+			capabilities.TypeScript = true
+		case types.FieldTailwindCSS:
+			capabilities.TailwindCSS = true
+		case types.FieldESLint:
+			capabilities.ESLint = true
+		default:
+			return capabilities, p.errorf("frontend %s: unknown option %q (available: typescript, tailwind, eslint)", gen.Name, option)
+		}
+	}
+
+	if len(gen.PackageManagers) > 0 {
+		capabilities.PackageManagers = nil
+		for _, name := range gen.PackageManagers {
+			pm, err := types.ParsePackageManager(string(name))
+			if err != nil {
+				return capabilities, p.errorf("frontend %s: %v", gen.Name, err)
+			}
+			capabilities.PackageManagers = append(capabilities.PackageManagers, pm)
+		}
+	}
+	return capabilities, nil
+}
+
+// errorf returns a types.ConfigError about the pack
+func (p *Pack) errorf(format string, args ...any) error {
+	return &types.ConfigError{Message: fmt.Sprintf("template pack %s: %s", p.Name(), fmt.Sprintf(format, args...))}
+}
@@ -0,0 +1,354 @@
+package pack_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/verse91/fsgo-dev-kit/internal/generator"
+	"github.com/verse91/fsgo-dev-kit/internal/pack"
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+// packFiles is a pack defining a backend and a frontend and extending fiber
+var packFiles = map[string]string{
+	pack.ManifestName: `name: acme
+backends:
+  - name: acme-chi
+    display-name: Acme Chi
+    dependencies: [github.com/go-chi/chi/v5]
+frontends:
+  - name: acme-web
+    display-name: Acme Web
+    options: [typescript]
+    package-managers: [bun, npm]
+    port: 5173
+    env-prefix: VITE_
+`,
+	"templates/acme-chi/server/main.go.tmpl":            "package main // {{.ModulePath}}\n",
+	"templates/acme-web/client/package.json.tmpl":       "{\"name\": \"{{.Name}}\"}\n",
+	"templates/fiber/server/internal/acme/acme.go.tmpl": "package acme\n",
+}
+
+// writeFiles writes files, keyed by slash-separated path, below dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// git runs git in dir and returns its trimmed output
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// newPackRepo creates a bare repository holding the pack, with VERSION set to
+// v1 in the commit tagged v1 and to v2 on the main branch. It returns the
+// path of the repository and the commit tagged v1.
+func newPackRepo(t *testing.T) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "fsgo")
+	t.Setenv("GIT_AUTHOR_EMAIL", "fsgo@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "fsgo")
+	t.Setenv("GIT_COMMITTER_EMAIL", "fsgo@example.com")
+
+	work := t.TempDir()
+	git(t, work, "init", "--quiet", "--initial-branch", "main")
+	writeFiles(t, work, packFiles)
+	writeFiles(t, work, map[string]string{"VERSION": "v1"})
+	git(t, work, "add", "-A")
+	git(t, work, "commit", "--quiet", "-m", "v1")
+	git(t, work, "tag", "v1")
+	v1 := git(t, work, "rev-parse", "HEAD")
+	writeFiles(t, work, map[string]string{"VERSION": "v2"})
+	git(t, work, "commit", "--quiet", "-am", "v2")
+
+	bare := filepath.Join(t.TempDir(), "acme-pack.git")
+	git(t, work, "clone", "--quiet", "--bare", work, bare)
+	return bare, v1
+}
+
+// version returns the VERSION of the pack checked out in dir
+func version(t *testing.T, dir string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, "VERSION"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestParseSource(t *testing.T) {
+	abs, err := filepath.Abs("acme-pack.git")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		in   string
+		want pack.Source
+	}{
+		{"../acme-pack", pack.Source{Dir: "../acme-pack"}},
+		{"https://github.com/acme/fsgo-pack", pack.Source{URL: "https://github.com/acme/fsgo-pack"}},
+		{"https://github.com/acme/fsgo-pack.git#v1.2.0", pack.Source{URL: "https://github.com/acme/fsgo-pack.git", Ref: "v1.2.0"}},
+		{"git@github.com:acme/fsgo-pack.git#main", pack.Source{URL: "git@github.com:acme/fsgo-pack.git", Ref: "main"}},
+		{"file:///srv/packs/acme.git", pack.Source{URL: "file:///srv/packs/acme.git"}},
+		{"acme-pack.git#v1", pack.Source{URL: abs, Ref: "v1"}},
+	}
+	for _, tt := range tests {
+		got, err := pack.ParseSource(tt.in)
+		if err != nil {
+			t.Errorf("ParseSource(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSource(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "#v1", "https://github.com/acme/fsgo-pack#", "../acme-pack#v1"} {
+		if _, err := pack.ParseSource(in); !errors.Is(err, types.ErrInvalidConfig) {
+			t.Errorf("ParseSource(%q) error = %v, want ErrInvalidConfig", in, err)
+		}
+	}
+}
+
+func TestFetch(t *testing.T) {
+	bare, v1 := newPackRepo(t)
+	cache := t.TempDir()
+	ctx := context.Background()
+
+	dir, err := pack.Source{URL: bare}.Fetch(ctx, cache)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if got := version(t, dir); got != "v2" {
+		t.Errorf("default branch checked out %s, want v2", got)
+	}
+
+	for _, ref := range []string{"v1", v1, v1[:10], "main"} {
+		pinned, err := pack.Source{URL: bare, Ref: ref}.Fetch(ctx, cache)
+		if err != nil {
+			t.Fatalf("Fetch #%s: %v", ref, err)
+		}
+		if pinned != dir {
+			t.Errorf("Fetch #%s cloned into %s, want the cached clone %s", ref, pinned, dir)
+		}
+		want := "v1"
+		if ref == "main" {
+			want = "v2"
+		}
+		if got := version(t, pinned); got != want {
+			t.Errorf("Fetch #%s checked out %s, want %s", ref, got, want)
+		}
+	}
+
+	entries, err := os.ReadDir(cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("cache holds %d entries, want only the clone", len(entries))
+	}
+
+	if _, err := (pack.Source{URL: bare, Ref: "v9"}).Fetch(ctx, cache); !errors.Is(err, types.ErrInvalidConfig) {
+		t.Errorf("Fetch of an unknown ref error = %v, want ErrInvalidConfig", err)
+	}
+
+	// Pinned tags are not fetched again, so they work without the remote;
+	// branches may have moved and need it
+	if err := os.RemoveAll(bare); err != nil {
+		t.Fatal(err)
+	}
+	if dir, err := (pack.Source{URL: bare, Ref: "v1"}).Fetch(ctx, cache); err != nil {
+		t.Errorf("Fetch #v1 without the remote: %v", err)
+	} else if got := version(t, dir); got != "v1" {
+		t.Errorf("Fetch #v1 without the remote checked out %s", got)
+	}
+	if _, err := (pack.Source{URL: bare, Ref: "main"}).Fetch(ctx, cache); err == nil {
+		t.Error("Fetch #main without the remote succeeded")
+	}
+}
+
+func TestOpenGitSource(t *testing.T) {
+	bare, _ := newPackRepo(t)
+	source, err := pack.ParseSource(bare + "#v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := pack.Open(context.Background(), source, t.TempDir())
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if p.Name() != "acme" || len(p.Manifest.Backends) != 1 || len(p.Manifest.Frontends) != 1 {
+		t.Errorf("Open read manifest %+v", p.Manifest)
+	}
+}
+
+// planWithPack loads the pack in dir and plans config with its generators
+// and templates
+func planWithPack(t *testing.T, dir string, config *types.ProjectConfig) *plan.Plan {
+	t.Helper()
+	p, err := pack.Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	registry := generator.NewGeneratorRegistry()
+	library := templates.NewLibrary(p.TemplatesPath())
+	if err := p.Register(registry, library); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	projectGen := generator.NewProjectGenerator()
+	projectGen.SetRegistry(registry)
+	projectGen.SetTemplates(library)
+	result, err := projectGen.Plan(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	return result
+}
+
+// written returns the content of the files p writes, by path
+func written(p *plan.Plan) map[string]string {
+	files := map[string]string{}
+	for _, action := range p.Actions() {
+		if action.Kind == plan.KindWrite {
+			files[action.Path] = string(action.Content)
+		}
+	}
+	return files
+}
+
+// commandLines returns the command lines of the run actions of p
+func commandLines(p *plan.Plan) []string {
+	var lines []string
+	for _, action := range p.Actions() {
+		if action.Kind == plan.KindRun {
+			lines = append(lines, action.Dir+": "+action.CommandLine())
+		}
+	}
+	return lines
+}
+
+func TestRegisterGenerators(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, packFiles)
+
+	p, err := pack.Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	registry := generator.NewGeneratorRegistry()
+	if err := p.Register(registry, templates.NewLibrary(p.TemplatesPath())); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	backend, err := registry.ParseBackendFramework("acme-chi")
+	if err != nil || backend != "Acme Chi" {
+		t.Errorf("ParseBackendFramework(acme-chi) = %q, %v", backend, err)
+	}
+	frontend, err := registry.ParseFrontendFramework("Acme Web")
+	if err != nil || frontend != "Acme Web" {
+		t.Errorf("ParseFrontendFramework(Acme Web) = %q, %v", frontend, err)
+	}
+
+	config := &types.ProjectConfig{
+		Name:             "shop",
+		Path:             "shop",
+		Type:             types.WebProject,
+		BackendFramework: "Acme Chi",
+		ModulePath:       "github.com/acme/shop/server",
+		Frontend:         &types.FrontendConfig{Framework: "Acme Web", TypeScript: true, PackageManager: types.Bun},
+	}
+	result := planWithPack(t, dir, config)
+
+	files := written(result)
+	if got := files["server/main.go"]; got != "package main // github.com/acme/shop/server\n" {
+		t.Errorf("server/main.go = %q", got)
+	}
+	if got := files["client/package.json"]; got != "{\"name\": \"shop\"}\n" {
+		t.Errorf("client/package.json = %q", got)
+	}
+	if !strings.Contains(files["README.md"], "localhost:5173") {
+		t.Error("README.md does not use the port of the pack frontend")
+	}
+
+	commands := commandLines(result)
+	for _, want := range []string{
+		"server: go mod init github.com/acme/shop/server",
+		"server: go get github.com/go-chi/chi/v5",
+		"client: bun install",
+	} {
+		if !slices.Contains(commands, want) {
+			t.Errorf("commands %q do not include %q", commands, want)
+		}
+	}
+
+	// The frontend settings stay with the library the pack was registered on
+	ctx := templates.NewContext(context.Background(), templates.Builtin())
+	if port := templates.NewData(ctx, config).Frontend.Port; port == 5173 {
+		t.Error("a library without the pack uses the port of the pack frontend")
+	}
+}
+
+func TestRegisterExtendsBuiltin(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, packFiles)
+
+	config := &types.ProjectConfig{Name: "shop", Path: "shop", Type: types.APIProject, BackendFramework: types.Fiber}
+	files := written(planWithPack(t, dir, config))
+	for _, path := range []string{"server/internal/acme/acme.go", "server/cmd/server/main.go"} {
+		if _, ok := files[path]; !ok {
+			t.Errorf("fiber with the pack does not write %s", path)
+		}
+	}
+}
+
+func TestRegisterRejectsTakenNames(t *testing.T) {
+	for _, manifest := range []string{
+		"backends:\n  - name: fiber\n",
+		"backends:\n  - name: acme\n    display-name: Gin\n",
+		"frontends:\n  - name: next\n",
+	} {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			pack.ManifestName:                    manifest,
+			"templates/fiber/README.md":          "fiber\n",
+			"templates/acme/server/main.go.tmpl": "package main\n",
+			"templates/next/README.md":           "next\n",
+		})
+		p, err := pack.Load(dir)
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		err = p.Register(generator.NewGeneratorRegistry(), templates.NewLibrary(p.TemplatesPath()))
+		if !errors.Is(err, types.ErrInvalidConfig) {
+			t.Errorf("Register with manifest %q error = %v, want ErrInvalidConfig", manifest, err)
+		}
+	}
+}
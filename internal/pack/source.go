@@ -0,0 +1,203 @@
+package pack
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+// gitTimeout limits each git command run to fetch a pack
+const gitTimeout = 5 * time.Minute
+
+// scpLikeURL matches git's scp-like syntax for ssh remotes, [user@]host:path,
+// as opposed to a local path containing a colon
+var scpLikeURL = regexp.MustCompile(`^([A-Za-z0-9._-]+@)?[A-Za-z0-9.-]+:[^/\\]`)
+
+// unsafeNameChars matches the characters left out of the names of clones
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// commitHash matches a full or abbreviated commit hash
+var commitHash = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// Source is where a template pack is read from: a local directory or a git
+// repository, optionally pinned to a ref
+type Source struct {
+	// Dir is the local directory of the pack; empty for git sources
+	Dir string
+	// URL is the git repository of the pack, e.g.
+	// https://github.com/acme/fsgo-pack.git or a local bare repository
+	URL string
+	// Ref is the branch, tag or commit to check out; empty means the default
+	// branch
+	Ref string
+}
+
+// ParseSource parses the argument of --template. URLs, scp-like remotes such
+// as git@github.com:acme/fsgo-pack.git and paths ending in .git are git
+// repositories; anything else is a local directory. A git source may be
+// pinned with a ref after '#', e.g. https://github.com/acme/fsgo-pack#v1.2.0.
+func ParseSource(s string) (Source, error) {
+	location, ref, pinned := strings.Cut(s, "#")
+	if location == "" || (pinned && ref == "") {
+		return Source{}, &types.ConfigError{Message: fmt.Sprintf("invalid template pack %q", s)}
+	}
+
+	switch {
+	case strings.Contains(location, "://") || scpLikeURL.MatchString(location):
+		return Source{URL: location, Ref: ref}, nil
+	case strings.HasSuffix(strings.TrimRight(location, `/\`), ".git"):
+		// A local repository; cloned by absolute path so the clone's remote
+		// keeps working from the cache
+		abs, err := filepath.Abs(location)
+		if err != nil {
+			return Source{}, err
+		}
+		return Source{URL: abs, Ref: ref}, nil
+	case pinned:
+		return Source{}, &types.ConfigError{Message: fmt.Sprintf("template pack %s is a directory, only git repositories can be pinned to a ref", location)}
+	}
+	return Source{Dir: location}, nil
+}
+
+// String returns the source as it is given to --template
+func (s Source) String() string {
+	switch {
+	case s.Dir != "":
+		return s.Dir
+	case s.Ref != "":
+		return s.URL + "#" + s.Ref
+	}
+	return s.URL
+}
+
+// Fetch returns the directory holding the pack. Git sources are cloned into
+// cacheDir on first use and fetched again on later ones, unless they are
+// pinned to a tag or commit that is already in the clone, so pinned packs
+// work offline. The ref, or the default branch, is then checked out.
+func (s Source) Fetch(ctx context.Context, cacheDir string) (string, error) {
+	if s.Dir != "" {
+		info, err := os.Stat(s.Dir)
+		if err != nil || !info.IsDir() {
+			return "", &types.ConfigError{Message: fmt.Sprintf("template pack directory %s does not exist", s.Dir)}
+		}
+		return s.Dir, nil
+	}
+
+	dir := filepath.Join(cacheDir, cacheKey(s.URL))
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+			return "", fmt.Errorf("error creating template pack cache: %w", err)
+		}
+		// Clone next to the final directory so an interrupted clone is
+		// never mistaken for a complete one
+		tmp, err := os.MkdirTemp(cacheDir, ".clone-*")
+		if err != nil {
+			return "", fmt.Errorf("error creating template pack cache: %w", err)
+		}
+		defer os.RemoveAll(tmp)
+		if _, err := git(ctx, "", "clone", "--quiet", "--no-checkout", s.URL, tmp); err != nil {
+			return "", fmt.Errorf("error cloning template pack %s: %w", s.URL, err)
+		}
+		if err := os.Rename(tmp, dir); err != nil {
+			return "", fmt.Errorf("error caching template pack %s: %w", s.URL, err)
+		}
+	} else if !s.pinnedIn(ctx, dir) {
+		if _, err := git(ctx, dir, "fetch", "--quiet", "--tags", "--force", "--prune", "origin"); err != nil {
+			return "", fmt.Errorf("error updating template pack %s: %w", s.URL, err)
+		}
+	}
+
+	commit, err := s.resolve(ctx, dir)
+	if err != nil {
+		return "", err
+	}
+	if _, err := git(ctx, dir, "checkout", "--quiet", "--force", "--detach", commit); err != nil {
+		return "", fmt.Errorf("error checking out %s of template pack %s: %w", commit, s.URL, err)
+	}
+	return dir, nil
+}
+
+// pinnedIn reports whether the source is pinned to a tag or commit that the
+// clone in dir already has. Branches move, so they are always fetched.
+func (s Source) pinnedIn(ctx context.Context, dir string) bool {
+	if s.Ref == "" {
+		return false
+	}
+	if _, err := git(ctx, dir, "rev-parse", "--verify", "--quiet", "refs/tags/"+s.Ref+"^{commit}"); err == nil {
+		return true
+	}
+	if !commitHash.MatchString(s.Ref) {
+		return false
+	}
+	_, err := git(ctx, dir, "rev-parse", "--verify", "--quiet", s.Ref+"^{commit}")
+	return err == nil
+}
+
+// resolve returns the commit of the clone in dir to check out: the ref as a
+// tag, a branch of the remote or a commit, or else the remote's default
+// branch
+func (s Source) resolve(ctx context.Context, dir string) (string, error) {
+	candidates := []string{"refs/remotes/origin/HEAD"}
+	if s.Ref != "" {
+		candidates = []string{"refs/tags/" + s.Ref, "refs/remotes/origin/" + s.Ref, s.Ref}
+	}
+	for _, candidate := range candidates {
+		if commit, err := git(ctx, dir, "rev-parse", "--verify", "--quiet", candidate+"^{commit}"); err == nil {
+			return commit, nil
+		}
+	}
+	if s.Ref == "" {
+		return "", fmt.Errorf("template pack %s has no default branch", s.URL)
+	}
+	return "", &types.ConfigError{Message: fmt.Sprintf("template pack %s has no branch, tag or commit %q", s.URL, s.Ref)}
+}
+
+// git runs git with args in dir, or the current directory if dir is empty,
+// and returns its trimmed output. Errors include what git printed.
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	// Fail instead of asking for credentials in the middle of the wizard
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return "", &types.MissingToolError{Tools: []string{"git"}}
+	}
+	if err != nil && ctx.Err() != nil {
+		// Killed because the run was interrupted or git took too long
+		return "", ctx.Err()
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// cacheKey names the clone of the repository at url in the cache: its base
+// name, for people looking at the cache, followed by a hash of the URL
+func cacheKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	base := strings.TrimSuffix(filepath.Base(strings.TrimRight(url, `/\`)), ".git")
+	base = unsafeNameChars.ReplaceAllString(base, "-")
+	return base + "-" + hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,325 @@
+package plan
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/verse91/fsgo-dev-kit/internal/events"
+	"github.com/verse91/fsgo-dev-kit/internal/runner"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/pkg/fsys"
+)
+
+// errGroupFailed stops the other groups of a segment once one has failed
+var errGroupFailed = errors.New("another group failed")
+
+// Executor applies plans. Commands are run with Runner; filesystems that are
+// not backed by a directory, such as in-memory ones, only record the files
+// and commands are skipped.
+type Executor struct {
+	Runner runner.Runner
+	// Events receives the steps, directories, files, commands, command
+	// output and warnings of the plan as they happen
+	Events events.Handler
+	// Jobs limits how many groups are applied at once; 0 means no limit
+	Jobs int
+	// Strict fails the generation when an optional command fails, instead
+	// of only warning
+	Strict bool
+
+	mu       sync.Mutex
+	warnings []string
+}
+
+// Warnings returns the warnings about optional commands that failed, in the
+// order they were reported
+func (e *Executor) Warnings() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.warnings...)
+}
+
+// Apply performs the actions of p against root, the filesystem rooted at the
+// project directory. Ungrouped actions are performed in order. Consecutive
+// groups write disjoint trees, so they are applied concurrently, each in
+// order, and the errors of all groups that failed are returned together.
+// Cancelling ctx stops the running commands and the remaining actions.
+func (e *Executor) Apply(ctx context.Context, p *Plan, root fsys.FS) error {
+	for _, segment := range segments(p.Actions()) {
+		var err error
+		if segment.groups == nil {
+			err = e.perform(ctx, root, "", segment.actions)
+		} else {
+			err = e.applyGroups(ctx, root, segment.groups)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyGroups applies groups concurrently, at most Jobs at a time. The first
+// group to fail stops the others, whose resulting cancellation errors are
+// dropped.
+func (e *Executor) applyGroups(ctx context.Context, root fsys.FS, groups []group) error {
+	groupCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	jobs := e.Jobs
+	if jobs <= 0 || jobs > len(groups) {
+		jobs = len(groups)
+	}
+	slots := make(chan struct{}, jobs)
+
+	errs := make([]error, len(groups))
+	var wg sync.WaitGroup
+	// Groups start in plan order as slots become free
+start:
+	for i, g := range groups {
+		select {
+		case slots <- struct{}{}:
+		case <-groupCtx.Done():
+			break start
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			if err := e.perform(groupCtx, root, g.name, g.actions); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", g.name, err)
+				cancel(errGroupFailed)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for i, err := range errs {
+		if errors.Is(err, context.Canceled) {
+			errs[i] = nil
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// perform performs the actions of group in order, reporting each step,
+// directory, file and command as events
+func (e *Executor) perform(ctx context.Context, root fsys.FS, group string, actions []Action) (err error) {
+	step, started := "", time.Time{}
+	finish := func(err error) {
+		if step == "" {
+			return
+		}
+		e.Events.Handle(events.Event{
+			Type:     events.StepFinished,
+			Group:    group,
+			Step:     step,
+			Status:   events.StatusOf(err),
+			Duration: time.Since(started),
+			Message:  errorMessage(err),
+		})
+	}
+	defer func() {
+		finish(err)
+	}()
+
+	for _, action := range actions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		switch action.Kind {
+		case KindStep:
+			finish(nil)
+			step, started = action.Message, time.Now()
+			e.Events.Handle(events.Event{Type: events.StepStarted, Group: group, Step: step})
+		case KindMkdir:
+			if err := root.MkdirAll(action.Path, action.Mode); err != nil {
+				return fmt.Errorf("error creating directory %s: %w", action.Path, err)
+			}
+			e.Events.Handle(events.Event{
+				Type:  events.DirCreated,
+				Group: group,
+				Step:  step,
+				Path:  action.Path,
+				Mode:  fmt.Sprintf("%04o", action.Mode.Perm()),
+			})
+		case KindWrite:
+			if err := writeFile(root, action); err != nil {
+				return fmt.Errorf("error creating file %s: %w", action.Path, err)
+			}
+			e.Events.Handle(events.Event{
+				Type:  events.FileWritten,
+				Group: group,
+				Step:  step,
+				Path:  action.Path,
+				Size:  events.Int(action.Size()),
+				Mode:  fmt.Sprintf("%04o", action.Mode.Perm()),
+			})
+		case KindRun:
+			if err := e.run(ctx, root, group, step, action); err != nil {
+				if !action.Optional || ctx.Err() != nil {
+					return fmt.Errorf("error running %s: %w", action.CommandLine(), err)
+				}
+				if e.Strict {
+					return fmt.Errorf("error running %s: %w: %w", action.CommandLine(), types.ErrDependencyInstall, err)
+				}
+				e.warn(group, step, fmt.Sprintf("%s failed: %v", action.CommandLine(), err))
+			}
+		default:
+			return fmt.Errorf("unknown plan action: %s", action.Kind)
+		}
+	}
+
+	return nil
+}
+
+// warn reports a warning about step of group and keeps it for Warnings
+func (e *Executor) warn(group, step, message string) {
+	e.mu.Lock()
+	e.warnings = append(e.warnings, message)
+	e.mu.Unlock()
+	e.Events.Handle(events.Event{Type: events.Warning, Group: group, Step: step, Message: message})
+}
+
+// writeFile writes the content of a write action, creating parent directories
+func writeFile(root fsys.FS, action Action) error {
+	if dir := path.Dir(action.Path); dir != "." {
+		if err := root.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return root.WriteFile(action.Path, action.Content, action.Mode)
+}
+
+// run runs the command of a run action in its directory below root,
+// reporting its start, every line of its output and its exit code
+func (e *Executor) run(ctx context.Context, root fsys.FS, group, step string, action Action) error {
+	sub, err := fsys.Sub(root, action.Dir)
+	if err != nil {
+		return err
+	}
+	dir := sub.Dir()
+	if dir == "" {
+		return nil
+	}
+
+	command := action.CommandLine()
+	e.Events.Handle(events.Event{Type: events.CommandStarted, Group: group, Step: step, Command: command, Dir: action.Dir})
+
+	out := &outputWriter{events: e.Events, group: group, step: step}
+	cmd := action.RunCommand(dir)
+	cmd.Output = out
+	start := time.Now()
+	err = e.Runner.Run(ctx, cmd)
+	out.Flush()
+
+	e.Events.Handle(events.Event{
+		Type:     events.CommandFinished,
+		Group:    group,
+		Step:     step,
+		Status:   events.StatusOf(err),
+		Command:  command,
+		Dir:      action.Dir,
+		ExitCode: events.Int(runner.ExitCode(err)),
+		Duration: time.Since(start),
+		Message:  errorMessage(err),
+	})
+	return err
+}
+
+// errorMessage returns the message of err, or "" if it is nil
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// outputWriter reports each line written to it as an output event. A
+// partial line is held back until it is complete or Flush is called.
+type outputWriter struct {
+	events events.Handler
+	group  string
+	step   string
+	buf    []byte
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		w.emit(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// Flush reports the pending partial line, if any
+func (w *outputWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.emit(string(w.buf))
+		w.buf = nil
+	}
+}
+
+// emit reports line, without a trailing carriage return
+func (w *outputWriter) emit(line string) {
+	w.events.Handle(events.Event{
+		Type:  events.Output,
+		Group: w.group,
+		Step:  w.step,
+		Line:  strings.TrimSuffix(line, "\r"),
+	})
+}
+
+// group holds the actions of one group of a plan
+type group struct {
+	name    string
+	actions []Action
+}
+
+// segment is either a run of ungrouped actions or a run of groups
+type segment struct {
+	actions []Action
+	groups  []group
+}
+
+// segments splits actions into runs of ungrouped actions and runs of groups,
+// keeping the groups in the order they first appear
+func segments(actions []Action) []segment {
+	var result []segment
+	for _, action := range actions {
+		grouped := action.Group != ""
+		if n := len(result); n == 0 || (result[n-1].groups != nil) != grouped {
+			result = append(result, segment{})
+		}
+		current := &result[len(result)-1]
+		if !grouped {
+			current.actions = append(current.actions, action)
+			continue
+		}
+
+		i := 0
+		for i < len(current.groups) && current.groups[i].name != action.Group {
+			i++
+		}
+		if i == len(current.groups) {
+			current.groups = append(current.groups, group{name: action.Group})
+		}
+		current.groups[i].actions = append(current.groups[i].actions, action)
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+package plan_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/verse91/fsgo-dev-kit/internal/events"
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/runner"
+	"github.com/verse91/fsgo-dev-kit/pkg/fsys"
+)
+
+// recorder keeps the events it handles
+type recorder struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (r *recorder) Handle(event events.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+// paths returns the paths of the events of type kind
+func (r *recorder) paths(kind events.Type) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var paths []string
+	for _, event := range r.events {
+		if event.Type == kind {
+			paths = append(paths, event.Path)
+		}
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// newPlan returns a plan writing a backend and a frontend group, each running
+// a command, followed by root files
+func newPlan() *plan.Plan {
+	p := plan.New()
+	p.Mkdir(".", plan.DirMode)
+
+	backend := p.Group("backend")
+	backend.Step("Initializing Go module")
+	backend.Mkdir("server", plan.DirMode)
+	backend.Sub("server").RunOptional(runner.Command{Name: "go", Args: []string{"mod", "init", "shop/server"}})
+	backend.Sub("server").WriteFile("cmd/server/main.go", "package main\n", plan.FileMode)
+
+	frontend := p.Group("frontend")
+	frontend.Step("Scaffolding app")
+	frontend.Run(runner.Command{Name: "bun", Args: []string{"create", "next-app@latest", "client"}})
+	frontend.Sub("client").WriteFile(".env", "PORT=3000\n", plan.FileMode)
+
+	p.Step("Writing root files")
+	p.WriteFile("Makefile", "run:\n", plan.FileMode)
+	p.WriteFile("scripts/dev.sh", "#!/bin/sh\n", 0o755)
+	return p
+}
+
+func TestApplyMemory(t *testing.T) {
+	root := fsys.NewMemory()
+	fake := runner.NewFake()
+	handler := &recorder{}
+	executor := &plan.Executor{Runner: fake, Events: handler}
+
+	if err := executor.Apply(context.Background(), newPlan(), root); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	want := []string{
+		"Makefile",
+		"client",
+		"client/.env",
+		"scripts",
+		"scripts/dev.sh",
+		"server",
+		"server/cmd",
+		"server/cmd/server",
+		"server/cmd/server/main.go",
+	}
+	if got := root.Paths(); !slices.Equal(got, want) {
+		t.Errorf("paths:\n got %q\nwant %q", got, want)
+	}
+
+	content, err := root.ReadFile("server/cmd/server/main.go")
+	if err != nil || string(content) != "package main\n" {
+		t.Errorf("server/cmd/server/main.go = %q, %v", content, err)
+	}
+	for name, mode := range map[string]fs.FileMode{"Makefile": 0o644, "scripts/dev.sh": 0o755} {
+		info, err := root.Stat(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != mode {
+			t.Errorf("%s has mode %04o, want %04o", name, info.Mode().Perm(), mode)
+		}
+	}
+
+	// An in-memory filesystem has no directory to run commands in
+	if commands := fake.Commands(); len(commands) != 0 {
+		t.Errorf("commands were run against an in-memory filesystem: %v", commands)
+	}
+	written := []string{"Makefile", "client/.env", "scripts/dev.sh", "server/cmd/server/main.go"}
+	if got := handler.paths(events.FileWritten); !slices.Equal(got, written) {
+		t.Errorf("file events:\n got %q\nwant %q", got, written)
+	}
+}
+
+func TestApplyReadOnly(t *testing.T) {
+	memory := fsys.NewMemory()
+	root := fsys.NewReadOnly(memory)
+	executor := &plan.Executor{Runner: runner.NewFake(), Events: &recorder{}}
+
+	err := executor.Apply(context.Background(), newPlan(), root)
+	if !errors.Is(err, fsys.ErrReadOnly) {
+		t.Fatalf("Apply error = %v, want ErrReadOnly", err)
+	}
+	if paths := memory.Paths(); len(paths) != 0 {
+		t.Errorf("read-only filesystem was written: %q", paths)
+	}
+}
+
+func TestApplyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	root := fsys.NewMemory()
+	executor := &plan.Executor{Runner: runner.NewFake(), Events: &recorder{}}
+	if err := executor.Apply(ctx, newPlan(), root); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Apply error = %v, want context.Canceled", err)
+	}
+	if paths := root.Paths(); len(paths) != 0 {
+		t.Errorf("cancelled Apply wrote %q", paths)
+	}
+}
@@ -0,0 +1,207 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/verse91/fsgo-dev-kit/internal/runner"
+)
+
+// Kind identifies what an action does
+type Kind string
+
+// Action kinds
+const (
+	// KindStep starts a new stage of the generation and only prints a message
+	KindStep Kind = "step"
+	// KindMkdir creates a directory along with any missing parents
+	KindMkdir Kind = "mkdir"
+	// KindWrite writes a file, creating its parent directories as needed
+	KindWrite Kind = "write"
+	// KindRun runs an external command
+	KindRun Kind = "run"
+)
+
+// Default permissions of created directories and files
+const (
+	DirMode  fs.FileMode = 0o755
+	FileMode fs.FileMode = 0o644
+)
+
+// Action is a single step of a plan. Paths and directories are
+// slash-separated and relative to the project root.
+type Action struct {
+	Kind Kind `json:"kind"`
+	// Message describes a step
+	Message string `json:"message,omitempty"`
+	// Path is the directory or file created by mkdir and write actions
+	Path string `json:"path,omitempty"`
+	// Mode is the permission of the created directory or file
+	Mode fs.FileMode `json:"-"`
+	// Content is the data written by a write action
+	Content []byte `json:"-"`
+	// Dir is the working directory of a run action
+	Dir string `json:"dir,omitempty"`
+	// Command is the program and arguments of a run action
+	Command []string `json:"command,omitempty"`
+	// Env holds the KEY=value environment overrides of a run action
+	Env []string `json:"env,omitempty"`
+	// Timeout limits how long a run action may take; zero means no limit
+	Timeout time.Duration `json:"-"`
+	// Stdin is what a run action reads on standard input
+	Stdin runner.Input `json:"stdin,omitempty"`
+	// Optional marks run actions setting up dependencies, such as go get,
+	// whose failure only warns unless the executor is strict
+	Optional bool `json:"optional,omitempty"`
+	// Group names the part of the project the action belongs to. Consecutive
+	// groups write disjoint trees and are applied concurrently.
+	Group string `json:"group,omitempty"`
+}
+
+// Size returns the number of bytes a write action writes
+func (a Action) Size() int {
+	return len(a.Content)
+}
+
+// RunCommand returns the command of a run action, to be run in dir
+func (a Action) RunCommand(dir string) runner.Command {
+	return runner.Command{
+		Name:    a.Command[0],
+		Args:    a.Command[1:],
+		Dir:     dir,
+		Env:     a.Env,
+		Timeout: a.Timeout,
+		Stdin:   a.Stdin,
+	}
+}
+
+// CommandLine returns the command of a run action as it would be typed in a
+// shell, quoting arguments where needed
+func (a Action) CommandLine() string {
+	return a.RunCommand("").String()
+}
+
+// MarshalJSON adds the size and octal mode of files and directories and the
+// timeout of commands, which are more useful to readers than the content and
+// the numeric mode and duration
+func (a Action) MarshalJSON() ([]byte, error) {
+	type action Action
+	out := struct {
+		action
+		Mode    string `json:"mode,omitempty"`
+		Size    *int   `json:"size,omitempty"`
+		Timeout string `json:"timeout,omitempty"`
+	}{action: action(a)}
+	if a.Timeout > 0 {
+		out.Timeout = a.Timeout.String()
+	}
+	switch a.Kind {
+	case KindMkdir:
+		out.Mode = fmt.Sprintf("%04o", a.Mode.Perm())
+	case KindWrite:
+		out.Mode = fmt.Sprintf("%04o", a.Mode.Perm())
+		size := a.Size()
+		out.Size = &size
+	}
+	return json.Marshal(out)
+}
+
+// Plan is the ordered list of actions generating a project performs. The same
+// plan is printed by --dry-run and applied for real generation.
+//
+// A plan created with Sub shares the actions of its parent and resolves
+// paths against a directory of it, so generators can add actions relative to
+// their own directory. A plan created with Group also tags every action it
+// adds with the group's name.
+type Plan struct {
+	actions *[]Action
+	dir     string
+	group   string
+}
+
+// New returns an empty plan rooted at the project directory
+func New() *Plan {
+	return &Plan{actions: &[]Action{}, dir: "."}
+}
+
+// Sub returns a plan that adds its actions to p, resolving paths against dir
+func (p *Plan) Sub(dir string) *Plan {
+	return &Plan{actions: p.actions, dir: p.join(dir), group: p.group}
+}
+
+// Group returns a plan that adds its actions to p as part of the group name
+func (p *Plan) Group(name string) *Plan {
+	return &Plan{actions: p.actions, dir: p.dir, group: name}
+}
+
+// Actions returns the actions added so far, in order
+func (p *Plan) Actions() []Action {
+	return *p.actions
+}
+
+// Tools returns the programs run by the plan, each once, in the order they
+// are first run
+func (p *Plan) Tools() []string {
+	var tools []string
+	for _, action := range p.Actions() {
+		if action.Kind == KindRun && !slices.Contains(tools, action.Command[0]) {
+			tools = append(tools, action.Command[0])
+		}
+	}
+	return tools
+}
+
+// Step starts a new stage of the generation, announced with message
+func (p *Plan) Step(message string) {
+	p.add(Action{Kind: KindStep, Message: message})
+}
+
+// Mkdir creates the directory name
+func (p *Plan) Mkdir(name string, mode fs.FileMode) {
+	p.add(Action{Kind: KindMkdir, Path: p.join(name), Mode: mode})
+}
+
+// WriteFile writes content to the file name
+func (p *Plan) WriteFile(name, content string, mode fs.FileMode) {
+	p.add(Action{Kind: KindWrite, Path: p.join(name), Mode: mode, Content: []byte(content)})
+}
+
+// Run runs cmd in the plan's directory, or in cmd.Dir below it. Generation
+// fails if the command fails.
+func (p *Plan) Run(cmd runner.Command) {
+	p.add(runAction(p.join(cmd.Dir), cmd, false))
+}
+
+// RunOptional runs cmd like Run, only warning if it fails unless the plan is
+// applied strictly. It is meant for commands setting up dependencies.
+func (p *Plan) RunOptional(cmd runner.Command) {
+	p.add(runAction(p.join(cmd.Dir), cmd, true))
+}
+
+// runAction returns the action running cmd in dir
+func runAction(dir string, cmd runner.Command, optional bool) Action {
+	return Action{
+		Kind:     KindRun,
+		Dir:      dir,
+		Command:  append([]string{cmd.Name}, cmd.Args...),
+		Env:      cmd.Env,
+		Timeout:  cmd.Timeout,
+		Stdin:    cmd.Stdin,
+		Optional: optional,
+	}
+}
+
+// add appends action to the shared list of actions
+func (p *Plan) add(action Action) {
+	action.Group = p.group
+	*p.actions = append(*p.actions, action)
+}
+
+// join resolves name against the plan's directory
+func (p *Plan) join(name string) string {
+	return path.Join(p.dir, name)
+}
@@ -0,0 +1,93 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/verse91/fsgo-dev-kit/internal/runner"
+	"github.com/verse91/fsgo-dev-kit/internal/tree"
+)
+
+// Print writes p in a human readable form: the steps that run commands with
+// those commands, then the tree of directories and files below root, the project
+// directory as given by the user
+func (p *Plan) Print(w io.Writer, root string) {
+	fmt.Fprintf(w, "Plan for %s (nothing has been written)\n", root)
+
+	var commands strings.Builder
+	header := ""
+	for _, action := range p.Actions() {
+		switch action.Kind {
+		case KindStep:
+			header = action.Message
+			if action.Group != "" {
+				header = "[" + action.Group + "] " + header
+			}
+		case KindRun:
+			if header != "" {
+				fmt.Fprintf(&commands, "\n%s\n", header)
+				header = ""
+			}
+			line := "  $ " + action.CommandLine()
+			if action.Dir != "." {
+				line += "  (in " + action.Dir + ")"
+			}
+			if action.Stdin == runner.Newlines {
+				line += "  [answers prompts with defaults]"
+			}
+			if action.Timeout > 0 {
+				line += fmt.Sprintf("  [timeout %s]", action.Timeout)
+			}
+			if action.Optional {
+				line += "  [optional]"
+			}
+			commands.WriteString(line + "\n")
+		}
+	}
+	if commands.Len() > 0 {
+		fmt.Fprintf(w, "\nCommands:\n%s", commands.String())
+	}
+
+	var paths []string
+	notes := map[string]string{}
+	for _, action := range p.Actions() {
+		switch action.Kind {
+		case KindMkdir:
+			if action.Path == "." {
+				continue
+			}
+			paths = append(paths, action.Path+"/")
+			notes[action.Path] = fmt.Sprintf("(%04o)", action.Mode.Perm())
+		case KindWrite:
+			paths = append(paths, action.Path)
+			notes[action.Path] = fmt.Sprintf("(%s, %04o)", formatSize(action.Size()), action.Mode.Perm())
+		}
+	}
+	fmt.Fprintf(w, "\nFiles:\n%s\n", tree.Render(root, paths, func(path string) string {
+		return notes[path]
+	}))
+	if commands.Len() > 0 {
+		fmt.Fprintln(w, "\nFiles created by the commands above are not listed.")
+	}
+}
+
+// PrintJSON writes p as JSON, along with root, the project directory as
+// given by the user
+func (p *Plan) PrintJSON(w io.Writer, root string) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Root    string   `json:"root"`
+		Actions []Action `json:"actions"`
+	}{root, p.Actions()})
+}
+
+// formatSize formats a file size in bytes for display
+func formatSize(size int) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f KiB", float64(size)/1024)
+}
@@ -0,0 +1,274 @@
+// Package progress displays the steps of a generation while they run: which
+// step each generator is on, how long it has taken and, when a step fails,
+// the output of the commands it ran.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/verse91/fsgo-dev-kit/internal/events"
+)
+
+// Mode selects how a Display shows progress
+type Mode int
+
+// Display modes
+const (
+	// Verbose streams the output of every command as it is written, each line
+	// prefixed with its group
+	Verbose Mode = iota
+	// Plain prints a line when a step starts and when it finishes. Command
+	// output is held back and only shown for steps that fail.
+	Plain
+	// Animated shows every step with a spinner and its elapsed time, redrawn
+	// in place while steps are running. Command output is handled as in
+	// Plain mode.
+	Animated
+)
+
+// tick is how often an animated display is redrawn
+const tick = 100 * time.Millisecond
+
+// spinnerFrames are the frames of the spinner shown next to running steps
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Display renders the events of a generation for people. It implements
+// events.Handler and may be used from several goroutines at once.
+type Display struct {
+	out   io.Writer
+	mode  Mode
+	mu    sync.Mutex
+	steps []*step
+	// shown are the steps in the animated list, which is redrawn in place
+	shown []*step
+	// current is the running step of each group
+	current map[string]*step
+	start   time.Time
+	// drawn is how many lines of the animated step list are on screen
+	drawn     int
+	frame     int
+	animating bool
+}
+
+// New returns a display writing to out in the given mode
+func New(out io.Writer, mode Mode) *Display {
+	return &Display{out: out, mode: mode, current: map[string]*step{}}
+}
+
+// Handle renders event
+func (d *Display) Handle(event events.Event) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch event.Type {
+	case events.StepStarted:
+		d.startStep(event)
+	case events.StepFinished:
+		d.finishStep(event)
+	case events.Output:
+		d.output(event)
+	case events.Warning:
+		d.print(fmt.Sprintf("%sWarning: %s\n", prefix(event.Group), event.Message))
+	case events.Notice:
+		d.print(event.Message + "\n")
+	case events.Done:
+		d.done(event)
+	}
+}
+
+// startStep adds the step of event to the list and, in Animated mode, starts
+// redrawing the list
+func (d *Display) startStep(event events.Event) {
+	s := &step{group: event.Group, name: event.Step, start: time.Now()}
+	if d.start.IsZero() {
+		d.start = s.start
+	}
+	d.steps = append(d.steps, s)
+	d.shown = append(d.shown, s)
+	d.current[event.Group] = s
+
+	switch d.mode {
+	case Verbose:
+		fmt.Fprintf(d.out, "%s🚀 %s...\n", prefix(s.group), s.name)
+	case Plain:
+		fmt.Fprintf(d.out, "• %s\n", s.label())
+	case Animated:
+		d.redraw()
+		if !d.animating {
+			d.animating = true
+			go d.animate()
+		}
+	}
+}
+
+// finishStep records the result of the step of event. Unless commands were
+// streamed in Verbose mode, the output of a step that failed for any reason
+// other than cancellation is shown.
+func (d *Display) finishStep(event events.Event) {
+	s := d.current[event.Group]
+	if s == nil || s.name != event.Step {
+		return
+	}
+	delete(d.current, event.Group)
+	s.status = event.Status
+	s.elapsed = event.Duration
+
+	switch d.mode {
+	case Verbose:
+		return
+	case Plain:
+		fmt.Fprintln(d.out, s.line(0))
+	case Animated:
+		d.redraw()
+	}
+
+	if s.status == events.StatusFailed && len(s.output) > 0 {
+		d.print(fmt.Sprintf("\nOutput of %s:\n    %s\n\n", s.label(), strings.Join(s.output, "\n    ")))
+	}
+}
+
+// output streams a line of command output in Verbose mode and otherwise
+// keeps it with the running step of its group, in case the step fails
+func (d *Display) output(event events.Event) {
+	if d.mode == Verbose {
+		fmt.Fprintf(d.out, "%s%s\n", prefix(event.Group), event.Line)
+		return
+	}
+	if s := d.current[event.Group]; s != nil {
+		s.output = append(s.output, event.Line)
+	}
+}
+
+// done writes how long each step took, where the command output was logged,
+// the warnings of the whole generation and how to run the new project
+func (d *Display) done(event events.Event) {
+	if len(d.steps) > 0 {
+		width := len("Total")
+		for _, s := range d.steps {
+			width = max(width, len(s.label()))
+		}
+		fmt.Fprintln(d.out, "\n⏱  Timing:")
+		for _, s := range d.steps {
+			fmt.Fprintf(d.out, "  %-*s  %s\n", width, s.label(), formatDuration(s.elapsed))
+		}
+		fmt.Fprintf(d.out, "  %-*s  %s\n", width, "Total", formatDuration(time.Since(d.start)))
+	}
+	if event.Log != "" {
+		fmt.Fprintf(d.out, "📄 Command output was logged to %s\n", event.Log)
+	}
+
+	if len(event.Warnings) > 0 {
+		fmt.Fprintf(d.out, "\n⚠️  Generated with %d warning(s):\n", len(event.Warnings))
+		for _, warning := range event.Warnings {
+			fmt.Fprintf(d.out, "  - %s\n", warning)
+		}
+		fmt.Fprintln(d.out)
+	}
+
+	fmt.Fprintf(d.out, "✅ Project %s created successfully!\n", event.Name)
+	if len(event.NextSteps) > 0 {
+		fmt.Fprintln(d.out, "\nNext steps:")
+		for _, next := range event.NextSteps {
+			fmt.Fprintf(d.out, "  %s\n", next)
+		}
+	}
+}
+
+// animate redraws the step list every tick while any step is running
+func (d *Display) animate() {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for range ticker.C {
+		d.mu.Lock()
+		if len(d.current) == 0 {
+			d.animating = false
+			d.mu.Unlock()
+			return
+		}
+		d.frame++
+		d.redraw()
+		d.mu.Unlock()
+	}
+}
+
+// redraw replaces the step list on screen with the current one. The caller
+// holds d.mu.
+func (d *Display) redraw() {
+	var b strings.Builder
+	if d.drawn > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA\r", d.drawn)
+	}
+	b.WriteString("\x1b[J")
+	for _, s := range d.shown {
+		b.WriteString(s.line(d.frame) + "\n")
+	}
+	d.drawn = len(d.shown)
+	io.WriteString(d.out, b.String())
+}
+
+// print writes text, keeping an animated step list below it while steps are
+// running. Otherwise the list stays above the text and later steps start a
+// new one. The caller holds d.mu.
+func (d *Display) print(text string) {
+	if d.mode != Animated || d.drawn == 0 || len(d.current) == 0 {
+		io.WriteString(d.out, text)
+		d.shown = nil
+		d.drawn = 0
+		return
+	}
+	fmt.Fprintf(d.out, "\x1b[%dA\r\x1b[J%s", d.drawn, text)
+	d.drawn = 0
+	d.redraw()
+}
+
+// step is a step of a generator as shown by the display
+type step struct {
+	group   string
+	name    string
+	start   time.Time
+	elapsed time.Duration
+	// status is empty while the step is running
+	status events.Status
+	// output holds the lines of command output, to be shown if it fails
+	output []string
+}
+
+// label names the step along with its group
+func (s *step) label() string {
+	return prefix(s.group) + s.name
+}
+
+// line shows the step's status, label and elapsed time, with the given
+// spinner frame while it is running
+func (s *step) line(frame int) string {
+	switch s.status {
+	case events.StatusOK:
+		return fmt.Sprintf("✓ %s (%s)", s.label(), formatDuration(s.elapsed))
+	case events.StatusFailed:
+		return fmt.Sprintf("✗ %s (failed after %s)", s.label(), formatDuration(s.elapsed))
+	case events.StatusCancelled:
+		return fmt.Sprintf("- %s (cancelled after %s)", s.label(), formatDuration(s.elapsed))
+	}
+	return fmt.Sprintf("%s %s (%s)", spinnerFrames[frame%len(spinnerFrames)], s.label(), formatDuration(time.Since(s.start)))
+}
+
+// prefix is written before lines belonging to group
+func prefix(group string) string {
+	if group == "" {
+		return ""
+	}
+	return "[" + group + "] "
+}
+
+// formatDuration formats d to a tenth of a second, with minutes once it
+// exceeds one
+func formatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return d.Round(time.Second).String()
+}
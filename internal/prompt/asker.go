@@ -0,0 +1,58 @@
+package prompt
+
+import (
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// asker asks single questions; it hides whether answers come from survey's
+// terminal widgets or from plain line-based input
+type asker interface {
+	// input asks for free text, returning defaultValue for an empty answer.
+	// Answers rejected by validate are reported and asked again.
+	input(message, help, defaultValue string, validate func(string) error) (string, error)
+	// choose asks for one of options, returning the chosen option
+	choose(message, help string, options []string, defaultValue string) (string, error)
+	// confirm asks a yes/no question
+	confirm(message string, defaultValue bool) (bool, error)
+}
+
+// surveyAsker asks questions with survey's interactive terminal prompts
+type surveyAsker struct{}
+
+func (surveyAsker) input(message, help, defaultValue string, validate func(string) error) (string, error) {
+	prompt := &survey.Input{
+		Message: message,
+		Default: defaultValue,
+		Help:    help,
+	}
+
+	var result string
+	err := survey.AskOne(prompt, &result, survey.WithValidator(func(ans interface{}) error {
+		return validate(ans.(string))
+	}))
+	return result, err
+}
+
+func (surveyAsker) choose(message, help string, options []string, defaultValue string) (string, error) {
+	prompt := &survey.Select{
+		Message: message,
+		Options: options,
+		Default: defaultValue,
+		Help:    help,
+	}
+
+	var result string
+	err := survey.AskOne(prompt, &result)
+	return result, err
+}
+
+func (surveyAsker) confirm(message string, defaultValue bool) (bool, error) {
+	prompt := &survey.Confirm{
+		Message: message,
+		Default: defaultValue,
+	}
+
+	var result bool
+	err := survey.AskOne(prompt, &result)
+	return result, err
+}
@@ -0,0 +1,55 @@
+package prompt
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/verse91/fsgo-dev-kit/internal/diff"
+	"github.com/verse91/fsgo-dev-kit/internal/staging"
+)
+
+// Conflict menu choices
+const (
+	conflictSkip      = "Skip (keep the existing file)"
+	conflictOverwrite = "Overwrite"
+	conflictDiff      = "Show diff"
+	conflictWriteNew  = "Write as %s"
+	conflictAbort     = "Abort generation"
+)
+
+// ResolveConflict asks what to do with a generated file that already exists
+// with different content: skip it, overwrite the existing file, show the
+// differences first or write the generated file next to it with the
+// .fsgo-new suffix
+func (p *ProjectPrompt) ResolveConflict(conflict staging.Conflict) (staging.Resolution, error) {
+	writeNew := fmt.Sprintf(conflictWriteNew, path.Base(conflict.Path)+staging.NewSuffix)
+	for {
+		choice, err := p.ask.choose(
+			fmt.Sprintf("%s already exists. What would you like to do?", conflict.Path),
+			"fsgo never replaces a file it did not create without asking",
+			[]string{conflictSkip, conflictOverwrite, conflictDiff, writeNew, conflictAbort},
+			conflictSkip,
+		)
+		if err != nil {
+			return "", err
+		}
+
+		switch choice {
+		case conflictSkip:
+			return staging.ResolveSkip, nil
+		case conflictOverwrite:
+			return staging.ResolveOverwrite, nil
+		case writeNew:
+			return staging.ResolveWriteNew, nil
+		case conflictAbort:
+			return staging.ResolveFail, nil
+		case conflictDiff:
+			fmt.Fprintln(p.out, diff.Unified(
+				path.Join("existing", conflict.Path),
+				path.Join("generated", conflict.Path),
+				string(conflict.Existing),
+				string(conflict.Generated),
+			))
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/verse91/fsgo-dev-kit/pkg/utils"
+)
+
+// IsPlainTerminal reports whether survey's interactive prompts can't be used:
+// stdin or stdout is not a terminal, or NO_COLOR is set
+func IsPlainTerminal() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return true
+	}
+	return !utils.IsTerminal(os.Stdin) || !utils.IsTerminal(os.Stdout)
+}
+
+// plainAsker asks questions as numbered, line-based text without ANSI escapes.
+// Answers are read one line at a time, so they can be piped on stdin; piped
+// answers are echoed so the transcript reads the same as a typed session.
+type plainAsker struct {
+	in   *bufio.Reader
+	out  io.Writer
+	echo bool
+}
+
+// readLine reads the next answer. An empty line selects the default.
+func (a *plainAsker) readLine(message string) (string, error) {
+	line, err := a.in.ReadString('\n')
+	if errors.Is(err, io.EOF) && line == "" {
+		fmt.Fprintln(a.out)
+		return "", fmt.Errorf("unexpected end of input while answering %q", message)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	answer := strings.TrimSpace(line)
+	if a.echo {
+		fmt.Fprintln(a.out, answer)
+	}
+	return answer, nil
+}
+
+func (a *plainAsker) input(message, help, defaultValue string, validate func(string) error) (string, error) {
+	for {
+		fmt.Fprintf(a.out, "%s [%s]: ", message, defaultValue)
+		answer, err := a.readLine(message)
+		if err != nil {
+			return "", err
+		}
+		if answer == "?" {
+			fmt.Fprintf(a.out, "  %s\n", help)
+			continue
+		}
+		if answer == "" {
+			answer = defaultValue
+		}
+		if err := validate(answer); err != nil {
+			fmt.Fprintf(a.out, "  %v\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+func (a *plainAsker) choose(message, help string, options []string, defaultValue string) (string, error) {
+	defaultIndex := 1
+	fmt.Fprintln(a.out, message)
+	for i, option := range options {
+		if option == defaultValue {
+			defaultIndex = i + 1
+		}
+		fmt.Fprintf(a.out, "  %d) %s\n", i+1, option)
+	}
+
+	for {
+		fmt.Fprintf(a.out, "Enter a number [%d]: ", defaultIndex)
+		answer, err := a.readLine(message)
+		if err != nil {
+			return "", err
+		}
+		if answer == "?" {
+			fmt.Fprintf(a.out, "  %s\n", help)
+			continue
+		}
+		if answer == "" {
+			return options[defaultIndex-1], nil
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return options[n-1], nil
+		}
+		for _, option := range options {
+			if strings.EqualFold(option, answer) {
+				return option, nil
+			}
+		}
+		fmt.Fprintf(a.out, "  Please enter a number between 1 and %d\n", len(options))
+	}
+}
+
+func (a *plainAsker) confirm(message string, defaultValue bool) (bool, error) {
+	hint := "y/N"
+	if defaultValue {
+		hint = "Y/n"
+	}
+
+	for {
+		fmt.Fprintf(a.out, "%s (%s): ", message, hint)
+		answer, err := a.readLine(message)
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return defaultValue, nil
+		case "y", "yes", "true":
+			return true, nil
+		case "n", "no", "false":
+			return false, nil
+		}
+		fmt.Fprintln(a.out, "  Please answer yes or no")
+	}
+}
@@ -0,0 +1,115 @@
+package prompt_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/verse91/fsgo-dev-kit/internal/generator"
+	"github.com/verse91/fsgo-dev-kit/internal/prompt"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+// answer runs the plain wizard on the given answer lines in a temporary
+// working directory and returns the configuration and the transcript
+func answer(t *testing.T, lines ...string) (*types.ProjectConfig, string, error) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll("taken", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("taken", "main.go"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	in := strings.NewReader(strings.Join(lines, "\n") + "\n")
+	config, err := prompt.NewPlainProjectPrompt(generator.NewGeneratorRegistry(), in, &out).GetProjectConfig()
+	return config, out.String(), err
+}
+
+func TestPlainWebProject(t *testing.T) {
+	config, transcript, err := answer(t,
+		"../outside", // rejected: leaves the current directory
+		"taken",      // not empty
+		"n",          // so pick another name
+		"shop",
+		"3", // out of range
+		"1",
+		"gin",       // options can be picked by name
+		"bad//path", // invalid module path
+		"github.com/acme/shop/server",
+		"", // default frontend, Next.js
+		"maybe",
+		"n",
+		"",
+		"y",
+		"pnpm",
+	)
+	if err != nil {
+		t.Fatalf("GetProjectConfig: %v\n%s", err, transcript)
+	}
+
+	want := &types.ProjectConfig{
+		Name:             "shop",
+		Path:             "shop",
+		Type:             types.WebProject,
+		BackendFramework: types.Gin,
+		ModulePath:       "github.com/acme/shop/server",
+		Frontend: &types.FrontendConfig{
+			Framework:      types.NextJS,
+			TypeScript:     false,
+			TailwindCSS:    true,
+			ESLint:         true,
+			PackageManager: types.Pnpm,
+		},
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("config = %+v, frontend %+v\nwant %+v, frontend %+v", config, config.Frontend, want, want.Frontend)
+	}
+
+	for _, reprompt := range []string{
+		`must not leave the current directory`,
+		`taken is not empty. Generate into it anyway? (y/N): n`,
+		`Please enter a number between 1 and 2`,
+		`invalid module path "bad//path"`,
+		`Please answer yes or no`,
+	} {
+		if !strings.Contains(transcript, reprompt) {
+			t.Errorf("transcript does not contain %q:\n%s", reprompt, transcript)
+		}
+	}
+	if strings.Contains(transcript, "\x1b[") {
+		t.Error("transcript contains ANSI escapes")
+	}
+}
+
+func TestPlainAPIProject(t *testing.T) {
+	config, transcript, err := answer(t, "api", "2", "", "")
+	if err != nil {
+		t.Fatalf("GetProjectConfig: %v\n%s", err, transcript)
+	}
+
+	want := &types.ProjectConfig{
+		Name:             "api",
+		Path:             "api",
+		Type:             types.APIProject,
+		BackendFramework: types.Fiber,
+		ModulePath:       "api/server",
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("config = %+v, want %+v", config, want)
+	}
+	if strings.Contains(transcript, "frontend") {
+		t.Errorf("API project was asked about the frontend:\n%s", transcript)
+	}
+}
+
+func TestPlainEndOfInput(t *testing.T) {
+	_, _, err := answer(t, "shop", "1")
+	if err == nil || !strings.Contains(err.Error(), "unexpected end of input") {
+		t.Errorf("error = %v, want unexpected end of input", err)
+	}
+}
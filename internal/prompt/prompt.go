@@ -1,89 +1,203 @@
 package prompt
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
-	"path/filepath"
+	"io"
+	"os"
+	"strings"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/internal/validate"
 	"github.com/verse91/fsgo-dev-kit/pkg/utils"
 )
 
 // ProjectPrompt handles interactive project configuration
-type ProjectPrompt struct{}
+type ProjectPrompt struct {
+	registry      types.FrameworkRegistry
+	defaults      *types.ProjectConfig
+	ask           asker
+	out           io.Writer
+	plain         bool
+	allowNonEmpty bool
+}
 
-// NewProjectPrompt creates a new project prompt
-func NewProjectPrompt() *ProjectPrompt {
-	return &ProjectPrompt{}
+// NewProjectPrompt creates a new project prompt offering the frameworks of registry
+func NewProjectPrompt(registry types.FrameworkRegistry) *ProjectPrompt {
+	return &ProjectPrompt{
+		registry: registry,
+		defaults: types.DefaultProjectConfig(),
+		ask:      surveyAsker{},
+		out:      os.Stdout,
+	}
+}
+
+// NewPlainProjectPrompt creates a project prompt for plain mode. Questions are
+// written to out as numbered, line-based text without box-drawing characters
+// or ANSI escapes, and answers are read line by line from in.
+func NewPlainProjectPrompt(registry types.FrameworkRegistry, in io.Reader, out io.Writer) *ProjectPrompt {
+	file, isFile := in.(*os.File)
+	echo := !isFile || !utils.IsTerminal(file)
+
+	return &ProjectPrompt{
+		registry: registry,
+		defaults: types.DefaultProjectConfig(),
+		ask:      &plainAsker{in: bufio.NewReader(in), out: out, echo: echo},
+		out:      out,
+		plain:    true,
+	}
+}
+
+// SetDefaults changes the answers the prompts start from
+func (p *ProjectPrompt) SetDefaults(defaults *types.ProjectConfig) {
+	if defaults.Frontend == nil {
+		defaults.Frontend = types.DefaultProjectConfig().Frontend
+	}
+	p.defaults = defaults
+}
+
+// SetAllowNonEmpty skips the confirmation asked before generating into a
+// directory that already contains files
+func (p *ProjectPrompt) SetAllowNonEmpty(allow bool) {
+	p.allowNonEmpty = allow
 }
 
 // GetProjectConfig prompts user for project configuration
 func (p *ProjectPrompt) GetProjectConfig() (*types.ProjectConfig, error) {
-	fmt.Println("┌  Creating a new project")
-	fmt.Println("│")
-
 	config := &types.ProjectConfig{}
-	
-	// Get project name/path
-	if err := p.promptProjectName(config); err != nil {
+	if err := p.CompleteProjectConfig(config, types.GetConfigFields()); err != nil {
 		return nil, err
 	}
+	return config, nil
+}
 
-	// Get project type
-	if err := p.promptProjectType(config); err != nil {
-		return nil, err
-	}
+// CompleteProjectConfig prompts only for the given fields of a partially
+// filled configuration. Frontend fields are skipped for API projects.
+func (p *ProjectPrompt) CompleteProjectConfig(config *types.ProjectConfig, fields []types.ConfigField) error {
+	p.begin("Creating a new project")
 
-	// Get backend framework
-	if err := p.promptBackendFramework(config); err != nil {
-		return nil, err
+	if err := p.promptFields(config, fields); err != nil {
+		return err
 	}
 
-	// Get frontend configuration (only for web projects)
-	if config.Type == types.WebProject {
-		if err := p.promptFrontendConfig(config); err != nil {
-			return nil, err
+	p.end("Configuration complete!")
+
+	return nil
+}
+
+// promptFields prompts for each of fields in order
+func (p *ProjectPrompt) promptFields(config *types.ProjectConfig, fields []types.ConfigField) error {
+	for _, field := range fields {
+		if err := p.promptField(config, field); err != nil {
+			return err
 		}
 	}
 
-	fmt.Println("└  Configuration complete!")
-	fmt.Println()
+	if config.Type != types.WebProject {
+		config.Frontend = nil
+	}
 
-	return config, nil
+	return nil
 }
 
-// promptProjectName prompts for project name/path
-func (p *ProjectPrompt) promptProjectName(config *types.ProjectConfig) error {
-	currentDir, err := utils.GetCurrentDir()
-	if err != nil {
-		return err
+// promptField prompts for a single configuration field
+func (p *ProjectPrompt) promptField(config *types.ProjectConfig, field types.ConfigField) error {
+	if types.IsFrontendField(field) {
+		// Get frontend configuration (only for web projects)
+		if config.Type != types.WebProject {
+			return nil
+		}
+		if config.Frontend == nil {
+			config.Frontend = &types.FrontendConfig{}
+		}
+		// Skip options the chosen frontend generator does not support
+		if field != types.FieldFrontend && !p.frontendCapabilities(config.Frontend).Supports(field) {
+			return p.frontendCapabilities(config.Frontend).Restrict(config.Frontend, types.FieldSet{})
+		}
 	}
 
-	projectName := filepath.Base(currentDir)
-	
-	prompt := &survey.Input{
-		Message: "Enter your project name or path (relative to current directory)",
-		Default: ".",
-		Help:    "Use '.' for current directory or specify a new directory name",
+	switch field {
+	case types.FieldPath:
+		return p.promptProjectName(config)
+	case types.FieldType:
+		return p.promptProjectType(config)
+	case types.FieldBackend:
+		return p.promptBackendFramework(config)
+	case types.FieldModulePath:
+		return p.promptModulePath(config)
+	case types.FieldFrontend:
+		return p.promptFrontendFramework(config.Frontend)
+	case types.FieldTypeScript:
+		return p.promptBoolOption("Use TypeScript?", &config.Frontend.TypeScript, p.defaults.Frontend.TypeScript)
+	case types.FieldTailwindCSS:
+		return p.promptBoolOption("Use Tailwind CSS?", &config.Frontend.TailwindCSS, p.defaults.Frontend.TailwindCSS)
+	case types.FieldESLint:
+		return p.promptBoolOption("Use ESLint?", &config.Frontend.ESLint, p.defaults.Frontend.ESLint)
+	case types.FieldPackageManager:
+		return p.promptPackageManager(config.Frontend)
 	}
 
-	var result string
-	if err := survey.AskOne(prompt, &result); err != nil {
-		return err
-	}
+	return fmt.Errorf("unknown configuration field: %s", field)
+}
+
+// promptProjectName prompts for project name/path
+func (p *ProjectPrompt) promptProjectName(config *types.ProjectConfig) error {
+	for {
+		result, err := p.ask.input(
+			"Enter your project name or path (relative to current directory)",
+			"Use '.' for current directory or specify a new directory name",
+			".",
+			validateProjectPath,
+		)
+		if err != nil {
+			return err
+		}
+
+		if !p.allowNonEmpty {
+			confirmed, err := p.confirmNonEmpty(result)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				continue
+			}
+		}
 
-	if result == "." {
+		projectName, err := utils.ProjectNameFromPath(result)
+		if err != nil {
+			return err
+		}
+		// A module path derived from the old path is derived again from the new one
+		if config.ModulePath != "" && config.ModulePath == utils.DefaultModulePath(config.Path, config.Name) {
+			config.ModulePath = ""
+		}
 		config.Name = projectName
-		config.Path = "."
-	} else {
-		config.Name = result
 		config.Path = result
+
+		p.step("Project", config.Name)
+
+		return nil
 	}
+}
 
-	fmt.Printf("◇  Project: %s\n", config.Name)
-	fmt.Println("│")
-	
-	return nil
+// confirmNonEmpty asks whether to generate into path when it already has files
+func (p *ProjectPrompt) confirmNonEmpty(path string) (bool, error) {
+	err := validate.TargetDir(path)
+	var notEmpty *validate.DirNotEmptyError
+	if !errors.As(err, &notEmpty) {
+		return true, err
+	}
+	return p.ask.confirm(fmt.Sprintf("%s is not empty. Generate into it anyway?", path), false)
+}
+
+// validateProjectPath checks a project path and the name derived from it
+func validateProjectPath(path string) error {
+	name, err := utils.ProjectNameFromPath(path)
+	if err != nil {
+		return err
+	}
+	return validate.Project(name, path)
 }
 
 // promptProjectType prompts for project type
@@ -94,152 +208,224 @@ func (p *ProjectPrompt) promptProjectType(config *types.ProjectConfig) error {
 		options[i] = string(pt)
 	}
 
-	prompt := &survey.Select{
-		Message: "Select project type",
-		Options: options,
-		Help:    "Web: Full-stack with frontend + backend, API: Backend only",
-	}
-
-	var result string
-	if err := survey.AskOne(prompt, &result); err != nil {
+	result, err := p.ask.choose(
+		"Select project type",
+		"Web: Full-stack with frontend + backend, API: Backend only",
+		options,
+		string(p.defaults.Type),
+	)
+	if err != nil {
 		return err
 	}
 
 	config.Type = types.ProjectType(result)
-	fmt.Printf("◇  Type: %s\n", result)
-	fmt.Println("│")
+	p.step("Type", result)
 
 	return nil
 }
 
 // promptBackendFramework prompts for backend framework
 func (p *ProjectPrompt) promptBackendFramework(config *types.ProjectConfig) error {
-	frameworks := types.GetBackendFrameworks()
+	frameworks := p.registry.GetAvailableBackendFrameworks()
 	options := make([]string, len(frameworks))
 	for i, fw := range frameworks {
 		options[i] = string(fw)
 	}
 
-	prompt := &survey.Select{
-		Message: "Choose backend framework",
-		Options: options,
-		Help:    "Select the Go web framework for your backend",
-	}
-
-	var result string
-	if err := survey.AskOne(prompt, &result); err != nil {
+	result, err := p.ask.choose(
+		"Choose backend framework",
+		"Select the Go web framework for your backend",
+		options,
+		defaultOption(options, string(p.defaults.BackendFramework)),
+	)
+	if err != nil {
 		return err
 	}
 
 	config.BackendFramework = types.BackendFramework(result)
-	fmt.Printf("◇  Backend: %s\n", result)
-	fmt.Println("│")
+	p.step("Backend", result)
 
 	return nil
 }
 
-// promptFrontendConfig prompts for frontend configuration
-func (p *ProjectPrompt) promptFrontendConfig(config *types.ProjectConfig) error {
-	config.Frontend = &types.FrontendConfig{}
-
-	// Get framework
-	if err := p.promptFrontendFramework(config.Frontend); err != nil {
-		return err
+// promptModulePath prompts for the Go module path of the backend, suggesting
+// the one derived from the git remote or the project name
+func (p *ProjectPrompt) promptModulePath(config *types.ProjectConfig) error {
+	defaultValue := config.ModulePath
+	if defaultValue == "" {
+		defaultValue = utils.DefaultModulePath(config.Path, config.Name)
 	}
 
-	// Get additional options
-	if err := p.promptFrontendOptions(config.Frontend); err != nil {
+	result, err := p.ask.input(
+		"Enter the Go module path of the backend",
+		"Used for go mod init and the imports of the server, e.g. github.com/you/my-app/server",
+		defaultValue,
+		validate.ModulePath,
+	)
+	if err != nil {
 		return err
 	}
 
+	config.ModulePath = result
+	p.step("Module path", result)
+
 	return nil
 }
 
 // promptFrontendFramework prompts for frontend framework
 func (p *ProjectPrompt) promptFrontendFramework(frontend *types.FrontendConfig) error {
-	frameworks := types.GetFrontendFrameworks()
+	frameworks := p.registry.GetAvailableFrontendFrameworks()
 	options := make([]string, len(frameworks))
 	for i, fw := range frameworks {
 		options[i] = string(fw)
 	}
 
-	prompt := &survey.Select{
-		Message: "Choose frontend framework",
-		Options: options,
-		Help:    "Select the frontend framework/library",
-	}
-
-	var result string
-	if err := survey.AskOne(prompt, &result); err != nil {
+	result, err := p.ask.choose(
+		"Choose frontend framework",
+		"Select the frontend framework/library",
+		options,
+		defaultOption(options, string(p.defaults.Frontend.Framework)),
+	)
+	if err != nil {
 		return err
 	}
 
 	frontend.Framework = types.FrontendFramework(result)
-	fmt.Printf("◇  Frontend: %s\n", result)
-	fmt.Println("│")
+	p.step("Frontend", result)
 
-	return nil
+	// Options left over from a previous framework may not apply to this one
+	return p.frontendCapabilities(frontend).Restrict(frontend, types.FieldSet{})
 }
 
-// promptFrontendOptions prompts for frontend configuration options
-func (p *ProjectPrompt) promptFrontendOptions(frontend *types.FrontendConfig) error {
-	// TypeScript
-	if err := p.promptBoolOption("Use TypeScript?", &frontend.TypeScript, true); err != nil {
-		return err
+// promptPackageManager prompts for the package manager used by the frontend
+func (p *ProjectPrompt) promptPackageManager(frontend *types.FrontendConfig) error {
+	managers := p.frontendCapabilities(frontend).PackageManagers
+	options := make([]string, len(managers))
+	for i, pm := range managers {
+		options[i] = string(pm)
 	}
 
-	// TailwindCSS
-	if err := p.promptBoolOption("Use Tailwind CSS?", &frontend.TailwindCSS, true); err != nil {
+	result, err := p.ask.choose(
+		"Choose package manager",
+		"Used to create the frontend and install its dependencies",
+		options,
+		defaultOption(options, string(p.defaults.Frontend.PackageManager)),
+	)
+	if err != nil {
 		return err
 	}
 
-	// ESLint
-	if err := p.promptBoolOption("Use ESLint?", &frontend.ESLint, true); err != nil {
-		return err
-	}
+	frontend.PackageManager = types.PackageManager(result)
+	p.step("Package manager", result)
 
 	return nil
 }
 
-// promptBoolOption prompts for a boolean configuration option
-func (p *ProjectPrompt) promptBoolOption(message string, target *bool, defaultValue bool) error {
-	prompt := &survey.Confirm{
-		Message: message,
-		Default: defaultValue,
+// frontendCapabilities returns the options supported by the chosen frontend
+func (p *ProjectPrompt) frontendCapabilities(frontend *types.FrontendConfig) types.FrontendCapabilities {
+	capabilities, _ := p.registry.GetFrontendCapabilities(frontend.Framework)
+	return capabilities
+}
+
+// defaultOption returns preferred when it is one of options and the first
+// option otherwise
+func defaultOption(options []string, preferred string) string {
+	for _, option := range options {
+		if option == preferred {
+			return option
+		}
+	}
+	if len(options) == 0 {
+		return ""
 	}
+	return options[0]
+}
 
-	if err := survey.AskOne(prompt, target); err != nil {
+// promptBoolOption prompts for a boolean configuration option
+func (p *ProjectPrompt) promptBoolOption(message string, target *bool, defaultValue bool) error {
+	result, err := p.ask.confirm(message, defaultValue)
+	if err != nil {
 		return err
 	}
+	*target = result
 
 	status := "No"
 	if *target {
 		status = "Yes"
 	}
-	
-	fmt.Printf("◇  %s %s\n", message, status)
-	fmt.Println("│")
+
+	p.step(message, status)
 
 	return nil
 }
 
 // ShowSummary displays the project configuration summary
 func (p *ProjectPrompt) ShowSummary(config *types.ProjectConfig) {
-	fmt.Println("┌  Project Summary")
-	fmt.Println("│")
-	fmt.Printf("│  Name: %s\n", config.Name)
-	fmt.Printf("│  Path: %s\n", config.Path)
-	fmt.Printf("│  Type: %s\n", config.Type)
-	fmt.Printf("│  Backend: %s\n", config.BackendFramework)
-	
+	p.begin("Project Summary")
+	p.detail("Name", config.Name)
+	p.detail("Path", config.Path)
+	p.detail("Type", string(config.Type))
+	p.detail("Backend", string(config.BackendFramework))
+	if config.ModulePath != "" {
+		p.detail("Module path", config.ModulePath)
+	}
+
 	if config.Frontend != nil {
-		fmt.Printf("│  Frontend: %s\n", config.Frontend.Framework)
-		fmt.Printf("│  TypeScript: %t\n", config.Frontend.TypeScript)
-		fmt.Printf("│  Tailwind CSS: %t\n", config.Frontend.TailwindCSS)
-		fmt.Printf("│  ESLint: %t\n", config.Frontend.ESLint)
-	}
-	
-	fmt.Println("│")
-	fmt.Println("└  Ready to generate!")
-	fmt.Println()
-}
\ No newline at end of file
+		p.detail("Frontend", string(config.Frontend.Framework))
+		p.detail("TypeScript", fmt.Sprint(config.Frontend.TypeScript))
+		p.detail("Tailwind CSS", fmt.Sprint(config.Frontend.TailwindCSS))
+		p.detail("ESLint", fmt.Sprint(config.Frontend.ESLint))
+		if config.Frontend.PackageManager != "" {
+			p.detail("Package manager", string(config.Frontend.PackageManager))
+		}
+	}
+
+	if !p.plain {
+		fmt.Fprintln(p.out, "│")
+	}
+	p.end("Ready to generate!")
+}
+
+// begin prints the heading of a group of questions or details
+func (p *ProjectPrompt) begin(title string) {
+	if p.plain {
+		fmt.Fprintln(p.out, title)
+		return
+	}
+	fmt.Fprintf(p.out, "┌  %s\n", title)
+	fmt.Fprintln(p.out, "│")
+}
+
+// step echoes an answered question
+func (p *ProjectPrompt) step(label, value string) {
+	// Questions already ending in "?" read better without a colon
+	separator := ": "
+	if strings.HasSuffix(label, "?") {
+		separator = " "
+	}
+	if p.plain {
+		fmt.Fprintf(p.out, "%s%s%s\n", label, separator, value)
+		return
+	}
+	fmt.Fprintf(p.out, "◇  %s%s%s\n", label, separator, value)
+	fmt.Fprintln(p.out, "│")
+}
+
+// detail prints one line of a summary
+func (p *ProjectPrompt) detail(label, value string) {
+	if p.plain {
+		fmt.Fprintf(p.out, "  %s: %s\n", label, value)
+		return
+	}
+	fmt.Fprintf(p.out, "│  %s: %s\n", label, value)
+}
+
+// end prints the closing line of a group
+func (p *ProjectPrompt) end(message string) {
+	if p.plain {
+		fmt.Fprintln(p.out, message)
+	} else {
+		fmt.Fprintf(p.out, "└  %s\n", message)
+	}
+	fmt.Fprintln(p.out)
+}
@@ -0,0 +1,146 @@
+package prompt
+
+import (
+	"fmt"
+
+	"github.com/verse91/fsgo-dev-kit/internal/spec"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/internal/userconfig"
+)
+
+// Review menu choices
+const (
+	reviewGenerate   = "Generate project"
+	reviewEdit       = "Edit an answer"
+	reviewSaveSpec   = "Save as spec file"
+	reviewSavePreset = "Save as preset"
+	reviewAbort      = "Abort"
+)
+
+// fieldLabels names each configuration field in the edit menu
+var fieldLabels = map[types.ConfigField]string{
+	types.FieldPath:           "Project name/path",
+	types.FieldType:           "Project type",
+	types.FieldBackend:        "Backend framework",
+	types.FieldModulePath:     "Go module path",
+	types.FieldFrontend:       "Frontend framework",
+	types.FieldTypeScript:     "TypeScript",
+	types.FieldTailwindCSS:    "Tailwind CSS",
+	types.FieldESLint:         "ESLint",
+	types.FieldPackageManager: "Package manager",
+}
+
+// Review shows the configuration summary followed by a menu to generate the
+// project, edit a single answer, save the answers as a spec file or preset, or
+// abort. It returns false when the user aborts; nothing is written in that case
+// except for spec files and presets the user explicitly saved.
+func (p *ProjectPrompt) Review(config *types.ProjectConfig) (bool, error) {
+	for {
+		p.ShowSummary(config)
+
+		choice, err := p.ask.choose(
+			"What would you like to do?",
+			"Generate writes the project to disk; editing jumps back to a single question",
+			[]string{reviewGenerate, reviewEdit, reviewSaveSpec, reviewSavePreset, reviewAbort},
+			reviewGenerate,
+		)
+		if err != nil {
+			return false, err
+		}
+
+		switch choice {
+		case reviewGenerate:
+			return true, nil
+		case reviewAbort:
+			return false, nil
+		case reviewEdit:
+			err = p.editField(config)
+		case reviewSaveSpec:
+			err = p.saveSpec(config)
+		case reviewSavePreset:
+			err = p.savePreset(config)
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// editField asks which answer to change and prompts for it again, together
+// with the answers that depend on it
+func (p *ProjectPrompt) editField(config *types.ProjectConfig) error {
+	var options []string
+	fields := make(map[string]types.ConfigField)
+	for _, field := range types.GetConfigFields() {
+		if types.IsFrontendField(field) && config.Type != types.WebProject {
+			continue
+		}
+		if types.IsFrontendField(field) && !p.frontendCapabilities(config.Frontend).Supports(field) {
+			continue
+		}
+		options = append(options, fieldLabels[field])
+		fields[fieldLabels[field]] = field
+	}
+
+	choice, err := p.ask.choose("Which answer do you want to change?", "Only the selected question is asked again", options, options[0])
+	if err != nil {
+		return err
+	}
+
+	return p.promptFields(config, dependentFields(config, fields[choice]))
+}
+
+// dependentFields returns field followed by the fields that have to be asked
+// again when it changes
+func dependentFields(config *types.ProjectConfig, field types.ConfigField) []types.ConfigField {
+	switch field {
+	case types.FieldPath:
+		// The suggested module path follows the project path
+		return []types.ConfigField{field, types.FieldModulePath}
+	case types.FieldType:
+		// Switching to Web needs a frontend; switching to API drops it
+		if config.Frontend == nil {
+			return []types.ConfigField{field, types.FieldFrontend, types.FieldTypeScript, types.FieldTailwindCSS, types.FieldESLint, types.FieldPackageManager}
+		}
+	case types.FieldFrontend:
+		return []types.ConfigField{field, types.FieldTypeScript, types.FieldTailwindCSS, types.FieldESLint, types.FieldPackageManager}
+	}
+	return []types.ConfigField{field}
+}
+
+// saveSpec writes the answers to a spec file chosen by the user
+func (p *ProjectPrompt) saveSpec(config *types.ProjectConfig) error {
+	path, err := p.ask.input("Spec file path", "Files ending in .json are written as JSON, anything else as YAML", "fsgo.yaml", requireValue)
+	if err != nil {
+		return err
+	}
+	if err := spec.Save(path, config); err != nil {
+		return fmt.Errorf("error saving spec file: %v", err)
+	}
+	p.step("Saved spec", path)
+	return nil
+}
+
+// savePreset stores the answers, minus name and path, as a named preset
+func (p *ProjectPrompt) savePreset(config *types.ProjectConfig) error {
+	name, err := p.ask.input("Preset name", "Use it later with 'fsgo new --preset <name>'", "default", func(name string) error {
+		_, err := userconfig.PresetPath(name)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := userconfig.SavePreset(name, config); err != nil {
+		return fmt.Errorf("error saving preset: %v", err)
+	}
+	p.step("Saved preset", name)
+	return nil
+}
+
+// requireValue rejects empty answers
+func requireValue(value string) error {
+	if value == "" {
+		return fmt.Errorf("a value is required")
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// LogDir is where transcripts are written, relative to the project directory
+const LogDir = ".fsgo/logs"
+
+// Exec runs commands as child processes. Their output is streamed to out and,
+// if set, copied to a transcript together with each command line, its working
+// directory, exit status and duration.
+type Exec struct {
+	out        io.Writer
+	mu         sync.Mutex
+	transcript io.Writer
+}
+
+// NewExec returns a runner that streams command output to out
+func NewExec(out io.Writer) *Exec {
+	return &Exec{out: out}
+}
+
+// SetTranscript copies the output of every following command to w
+func (e *Exec) SetTranscript(w io.Writer) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.transcript = w
+}
+
+// waitDelay is how long Run waits for the output of a cancelled command to
+// be closed before giving up on it
+const waitDelay = 5 * time.Second
+
+// Run runs cmd in its own process group and waits for it to finish. When ctx
+// is cancelled or the timeout expires, the whole group is killed so package
+// managers don't leave installs running in the background.
+func (e *Exec) Run(ctx context.Context, cmd Command) error {
+	runCtx := ctx
+	if cmd.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, cmd.Timeout)
+		defer cancel()
+	}
+
+	c := exec.CommandContext(runCtx, cmd.Name, cmd.Args...)
+	c.Dir = cmd.Dir
+	if len(cmd.Env) > 0 {
+		c.Env = append(os.Environ(), cmd.Env...)
+	}
+	c.Stdin = stdin(cmd.Stdin)
+	setProcessGroup(c)
+	c.Cancel = func() error {
+		return killProcessGroup(c)
+	}
+	c.WaitDelay = waitDelay
+
+	output := e.out
+	if cmd.Output != nil {
+		output = cmd.Output
+	}
+	e.mu.Lock()
+	transcript := e.transcript
+	e.mu.Unlock()
+	// Commands may run concurrently, so each one's output is collected and
+	// added to the transcript as a single block once it has finished
+	block := &lockedBuffer{}
+	if transcript != nil {
+		fmt.Fprintf(block, "$ %s\n# in %s\n", cmd, displayDir(cmd.Dir))
+		output = io.MultiWriter(output, block)
+	}
+	c.Stdout = output
+	c.Stderr = output
+
+	start := time.Now()
+	err := c.Run()
+	switch {
+	case ctx.Err() != nil:
+		err = ctx.Err()
+	case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+		err = fmt.Errorf("timed out after %s", cmd.Timeout)
+	}
+
+	if transcript != nil {
+		status := "ok"
+		if err != nil {
+			status = err.Error()
+		}
+		fmt.Fprintf(block, "# %s after %s\n\n", status, time.Since(start).Round(time.Millisecond))
+		e.mu.Lock()
+		block.WriteTo(transcript)
+		e.mu.Unlock()
+	}
+	return err
+}
+
+// ExitCode returns the exit code of a command that ended with err: 0 if it
+// succeeded, the code it exited with, or -1 if it was killed or could not be
+// started
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// displayDir returns the working directory shown in transcripts
+func displayDir(dir string) string {
+	if dir == "" {
+		return "."
+	}
+	return dir
+}
+
+// lockedBuffer is a buffer that is safe to use while a command that was given
+// up on after waitDelay may still be writing to it
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// WriteTo writes the buffered data to w, emptying the buffer
+func (b *lockedBuffer) WriteTo(w io.Writer) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.WriteTo(w)
+}
+
+// CreateTranscript creates a new transcript file in the LogDir of the
+// project directory dir
+func CreateTranscript(dir string) (*os.File, error) {
+	logDir := filepath.Join(dir, filepath.FromSlash(LogDir))
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating log directory: %v", err)
+	}
+	name := fmt.Sprintf("generate-%s.log", time.Now().Format("20060102-150405"))
+	file, err := os.Create(filepath.Join(logDir, name))
+	if err != nil {
+		return nil, fmt.Errorf("error creating transcript: %v", err)
+	}
+	return file, nil
+}
@@ -0,0 +1,142 @@
+//go:build unix
+
+package runner_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/verse91/fsgo-dev-kit/internal/runner"
+)
+
+// quickly is well below the time Run waits for the output of a killed
+// command, so returning within it shows the whole process group was killed
+const quickly = 3 * time.Second
+
+// waitForFile waits until path exists and returns its trimmed content
+func waitForFile(t *testing.T, path string) string {
+	t.Helper()
+	deadline := time.Now().Add(quickly)
+	for time.Now().Before(deadline) {
+		if content, err := os.ReadFile(path); err == nil && len(bytes.TrimSpace(content)) > 0 {
+			return string(bytes.TrimSpace(content))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s was not written", path)
+	return ""
+}
+
+// gone reports whether the process pid has exited. A zombie that was not
+// reaped yet counts as exited.
+func gone(pid int) bool {
+	if err := syscall.Kill(pid, 0); errors.Is(err, syscall.ESRCH) {
+		return true
+	}
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return false
+	}
+	// The state follows the command name, which is in parentheses
+	fields := strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+	return len(fields) > 0 && fields[0] == "Z"
+}
+
+// runGroup runs a shell that starts a grandchild sleeping in the background
+// and waits for it, and returns how long Run took after stop was called, the
+// pid of the grandchild and the error of Run
+func runGroup(t *testing.T, ctx context.Context, timeout time.Duration, stop func()) (time.Duration, int, error) {
+	t.Helper()
+	pidFile := filepath.Join(t.TempDir(), "pid")
+	var out bytes.Buffer
+	cmd := runner.Command{
+		Name:    "sh",
+		Args:    []string{"-c", `sleep 30 & echo $! > "$0"; echo started; wait`, pidFile},
+		Timeout: timeout,
+		Output:  &out,
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- runner.NewExec(&out).Run(ctx, cmd)
+	}()
+
+	pid, err := strconv.Atoi(waitForFile(t, pidFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		syscall.Kill(pid, syscall.SIGKILL)
+	})
+
+	stopped := time.Now()
+	stop()
+	select {
+	case err := <-done:
+		return time.Since(stopped), pid, err
+	case <-time.After(2 * quickly):
+		t.Fatal("Run did not return after the command was stopped")
+		return 0, pid, nil
+	}
+}
+
+// assertGone fails t unless the process pid exits soon
+func assertGone(t *testing.T, pid int) {
+	t.Helper()
+	deadline := time.Now().Add(quickly)
+	for !gone(pid) {
+		if time.Now().After(deadline) {
+			t.Fatalf("grandchild %d is still running", pid)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRunCancelKillsProcessGroup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	elapsed, pid, err := runGroup(t, ctx, 0, cancel)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run error = %v, want context.Canceled", err)
+	}
+	if elapsed > quickly {
+		t.Errorf("Run returned %s after cancellation", elapsed)
+	}
+	if code := runner.ExitCode(err); code != -1 {
+		t.Errorf("ExitCode = %d, want -1", code)
+	}
+	assertGone(t, pid)
+}
+
+func TestRunTimeoutKillsProcessGroup(t *testing.T) {
+	timeout := 500 * time.Millisecond
+	elapsed, pid, err := runGroup(t, context.Background(), timeout, func() {})
+	if err == nil || !strings.Contains(err.Error(), "timed out after "+timeout.String()) {
+		t.Errorf("Run error = %v, want a timeout", err)
+	}
+	if elapsed > timeout+quickly {
+		t.Errorf("Run returned %s after the command started", elapsed)
+	}
+	assertGone(t, pid)
+}
+
+func TestRunExitCode(t *testing.T) {
+	var out bytes.Buffer
+	err := runner.NewExec(&out).Run(context.Background(), runner.Command{Name: "sh", Args: []string{"-c", "echo failing; exit 3"}})
+	if code := runner.ExitCode(err); code != 3 {
+		t.Errorf("ExitCode(%v) = %d, want 3", err, code)
+	}
+	if out.String() != "failing\n" {
+		t.Errorf("output = %q", out.String())
+	}
+}
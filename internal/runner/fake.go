@@ -0,0 +1,45 @@
+package runner
+
+import (
+	"context"
+	"sync"
+)
+
+// Fake records the commands it is asked to run instead of running them, so
+// generators can be exercised without Go, bun or npx installed
+type Fake struct {
+	mu       sync.Mutex
+	commands []Command
+	// Handle, if set, is called for every command and decides its result,
+	// e.g. to simulate a failing command or create the files it would
+	Handle func(ctx context.Context, cmd Command) error
+}
+
+// NewFake returns a fake runner on which every command succeeds
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+// Run records cmd and returns the result of Handle, or ctx's error if it is
+// already cancelled
+func (f *Fake) Run(ctx context.Context, cmd Command) error {
+	f.mu.Lock()
+	f.commands = append(f.commands, cmd)
+	handle := f.Handle
+	f.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if handle != nil {
+		return handle(ctx, cmd)
+	}
+	return nil
+}
+
+// Commands returns the commands run so far, in order
+func (f *Fake) Commands() []Command {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Command(nil), f.commands...)
+}
@@ -0,0 +1,13 @@
+//go:build !unix && !windows
+
+package runner
+
+import "os/exec"
+
+// setProcessGroup does nothing on platforms without process groups
+func setProcessGroup(c *exec.Cmd) {}
+
+// killProcessGroup kills c; processes it started are left running
+func killProcessGroup(c *exec.Cmd) error {
+	return c.Process.Kill()
+}
@@ -0,0 +1,19 @@
+//go:build unix
+
+package runner
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts c in a new process group, so it and its children
+// can be killed together and don't receive the terminal's Ctrl-C directly
+func setProcessGroup(c *exec.Cmd) {
+	c.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills the process group of c
+func killProcessGroup(c *exec.Cmd) error {
+	return syscall.Kill(-c.Process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,20 @@
+//go:build windows
+
+package runner
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// setProcessGroup starts c in a new process group, so it doesn't receive the
+// console's Ctrl-C directly
+func setProcessGroup(c *exec.Cmd) {
+	c.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// killProcessGroup kills c and every process it started
+func killProcessGroup(c *exec.Cmd) error {
+	return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(c.Process.Pid)).Run()
+}
@@ -0,0 +1,86 @@
+package runner
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+)
+
+// Input is what a command reads on stdin
+type Input string
+
+// Command inputs
+const (
+	// NoInput connects stdin to the null device
+	NoInput Input = ""
+	// Newlines answers every prompt of the command with its default by
+	// feeding it an endless stream of newlines
+	Newlines Input = "newlines"
+)
+
+// Command is an external command. It is run directly, never through a
+// shell, so arguments are passed exactly as given.
+type Command struct {
+	Name string
+	Args []string
+	// Dir is the working directory; empty means the current directory
+	Dir string
+	// Env holds KEY=value overrides added to the inherited environment
+	Env []string
+	// Timeout stops the command after the given duration; zero means no limit
+	Timeout time.Duration
+	// Stdin is what the command reads on standard input
+	Stdin Input
+	// Output receives the command's output instead of the runner's default
+	// writer when set
+	Output io.Writer
+}
+
+// String returns the command as it would be typed in a shell, with its
+// environment overrides and arguments quoted where needed
+func (c Command) String() string {
+	words := make([]string, 0, len(c.Env)+len(c.Args)+1)
+	words = append(words, c.Env...)
+	words = append(words, c.Name)
+	words = append(words, c.Args...)
+	for i, word := range words {
+		words[i] = shellQuote(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// Runner runs external commands. Generators only describe commands; the
+// runner decides how they are executed, so tests can swap in a Fake.
+type Runner interface {
+	// Run runs cmd and waits for it to finish. Cancelling ctx stops the
+	// command along with any processes it started.
+	Run(ctx context.Context, cmd Command) error
+}
+
+// stdin returns the reader for input, or nil for NoInput
+func stdin(input Input) io.Reader {
+	if input == Newlines {
+		return newlineReader{}
+	}
+	return nil
+}
+
+// newlineReader reads an endless stream of newlines, like `yes ""`
+type newlineReader struct{}
+
+func (newlineReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = '\n'
+	}
+	return len(p), nil
+}
+
+// shellQuote wraps s in single quotes if a shell would otherwise split or
+// interpret it
+func shellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n\"'\\|&;<>()$`*?[]#~{}!") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,277 @@
+package spec
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/internal/validate"
+	"github.com/verse91/fsgo-dev-kit/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Error describes a problem at a specific line of a spec file
+type Error struct {
+	File string
+	Line int
+	Msg  string
+	// Err classifies the problem; nil means types.ErrInvalidConfig
+	Err error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+}
+
+// Unwrap returns Err, or types.ErrInvalidConfig when it is nil
+func (e *Error) Unwrap() error {
+	if e.Err != nil {
+		return e.Err
+	}
+	return types.ErrInvalidConfig
+}
+
+// Errors collects every problem found in a spec file
+type Errors []*Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the collected errors, so the errors classify as every
+// sentinel one of them wraps, such as types.ErrUnknownFramework for a
+// framework that is not registered
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// decoder walks a YAML node tree and records line-numbered errors
+type decoder struct {
+	file     string
+	registry types.FrameworkRegistry
+	errs     Errors
+}
+
+// errorf records an error at the line of node
+func (d *decoder) errorf(node *yaml.Node, format string, args ...any) {
+	d.errs = append(d.errs, &Error{File: d.file, Line: node.Line, Msg: fmt.Sprintf(format, args...)})
+}
+
+// wrapf records an error at the line of node that is classified by err
+func (d *decoder) wrapf(node *yaml.Node, err error, format string, args ...any) {
+	d.errs = append(d.errs, &Error{File: d.file, Line: node.Line, Msg: fmt.Sprintf(format, args...), Err: err})
+}
+
+// fields returns the key/value pairs of a mapping node
+func (d *decoder) fields(node *yaml.Node, what string) [][2]*yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		d.errorf(node, "%s must be a mapping", what)
+		return nil
+	}
+	pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+	}
+	return pairs
+}
+
+// scalar returns the string value of a scalar node
+func (d *decoder) scalar(node *yaml.Node, key string) (string, bool) {
+	if node.Kind != yaml.ScalarNode {
+		d.errorf(node, "%s must be a string", key)
+		return "", false
+	}
+	return node.Value, true
+}
+
+// boolean returns the value of a boolean scalar node
+func (d *decoder) boolean(node *yaml.Node, key string) (bool, bool) {
+	var value bool
+	if node.Kind != yaml.ScalarNode || node.Decode(&value) != nil {
+		d.errorf(node, "%s must be true or false", key)
+		return false, false
+	}
+	return value, true
+}
+
+// decodeProject decodes the top-level spec mapping into config
+func (d *decoder) decodeProject(node *yaml.Node, config *types.ProjectConfig, set types.FieldSet) {
+	var frontendNode *yaml.Node
+	for _, pair := range d.fields(node, "spec") {
+		key, value := pair[0], pair[1]
+		switch key.Value {
+		case "version":
+			var version int
+			if err := value.Decode(&version); err != nil || version != Version {
+				d.errorf(value, "unsupported spec version %q (supported: %d)", value.Value, Version)
+			}
+		case "name":
+			if name, ok := d.scalar(value, key.Value); ok {
+				config.Name = name
+				set[types.FieldPath] = true
+			}
+		case "path":
+			if path, ok := d.scalar(value, key.Value); ok {
+				config.Path = path
+				set[types.FieldPath] = true
+			}
+		case "type":
+			if s, ok := d.scalar(value, key.Value); ok {
+				projectType, err := types.ParseProjectType(s)
+				if err != nil {
+					d.errorf(value, "%v (available: %s)", err, joinNames(types.GetProjectTypes()))
+					continue
+				}
+				config.Type = projectType
+				set[types.FieldType] = true
+			}
+		case "backend":
+			if s, ok := d.scalar(value, key.Value); ok {
+				d.decodeBackend(value, s, config, set)
+			}
+		case "module-path":
+			if modulePath, ok := d.scalar(value, key.Value); ok {
+				if err := validate.ModulePath(modulePath); err != nil {
+					d.errorf(value, "%v", err)
+					continue
+				}
+				config.ModulePath = modulePath
+				set[types.FieldModulePath] = true
+			}
+		case "frontend":
+			frontendNode = value
+			d.decodeFrontend(value, config, set)
+		default:
+			d.errorf(key, "unknown field %q", key.Value)
+		}
+	}
+
+	if set[types.FieldPath] {
+		if config.Path == "" {
+			config.Path = config.Name
+		}
+		if config.Name == "" {
+			name, err := utils.ProjectNameFromPath(config.Path)
+			if err != nil {
+				d.errorf(node, "%v", err)
+			}
+			config.Name = name
+		}
+	}
+
+	if frontendNode != nil && set[types.FieldType] && config.Type != types.WebProject {
+		d.errorf(frontendNode, "frontend is only valid for %s projects, not %s", types.WebProject, config.Type)
+	}
+}
+
+// decodeBackend validates the backend framework against the registry
+func (d *decoder) decodeBackend(node *yaml.Node, s string, config *types.ProjectConfig, set types.FieldSet) {
+	available := d.registry.GetAvailableBackendFrameworks()
+	framework, err := d.registry.ParseBackendFramework(s)
+	if err == nil && !slices.Contains(available, framework) {
+		err = &types.UnknownFrameworkError{Kind: "backend", Name: string(framework)}
+	}
+	if err != nil {
+		d.wrapf(node, err, "%v (available: %s)", err, joinNames(available))
+		return
+	}
+	config.BackendFramework = framework
+	set[types.FieldBackend] = true
+}
+
+// decodeFrontend decodes the frontend mapping into config.Frontend
+func (d *decoder) decodeFrontend(node *yaml.Node, config *types.ProjectConfig, set types.FieldSet) {
+	config.Frontend = &types.FrontendConfig{}
+	options := make(map[types.ConfigField]*yaml.Node)
+	for _, pair := range d.fields(node, "frontend") {
+		key, value := pair[0], pair[1]
+		switch key.Value {
+		case "framework":
+			s, ok := d.scalar(value, key.Value)
+			if !ok {
+				continue
+			}
+			available := d.registry.GetAvailableFrontendFrameworks()
+			framework, err := d.registry.ParseFrontendFramework(s)
+			if err == nil && !slices.Contains(available, framework) {
+				err = &types.UnknownFrameworkError{Kind: "frontend", Name: string(framework)}
+			}
+			if err != nil {
+				d.wrapf(value, err, "%v (available: %s)", err, joinNames(available))
+				continue
+			}
+			config.Frontend.Framework = framework
+			set[types.FieldFrontend] = true
+		case "typescript":
+			d.decodeOption(value, key.Value, &config.Frontend.TypeScript, types.FieldTypeScript, set, options)
+		case "tailwind":
+			d.decodeOption(value, key.Value, &config.Frontend.TailwindCSS, types.FieldTailwindCSS, set, options)
+		case "eslint":
+			d.decodeOption(value, key.Value, &config.Frontend.ESLint, types.FieldESLint, set, options)
+		case "package-manager":
+			s, ok := d.scalar(value, key.Value)
+			if !ok {
+				continue
+			}
+			pm, err := types.ParsePackageManager(s)
+			if err != nil {
+				d.errorf(value, "%v", err)
+				continue
+			}
+			config.Frontend.PackageManager = pm
+			set[types.FieldPackageManager] = true
+			options[types.FieldPackageManager] = value
+		default:
+			d.errorf(key, "unknown frontend field %q", key.Value)
+		}
+	}
+
+	d.checkCapabilities(config.Frontend, set, options)
+}
+
+// decodeOption decodes a boolean frontend option and remembers its node
+func (d *decoder) decodeOption(node *yaml.Node, key string, target *bool, field types.ConfigField, set types.FieldSet, options map[types.ConfigField]*yaml.Node) {
+	if v, ok := d.boolean(node, key); ok {
+		*target = v
+		set[field] = true
+		options[field] = node
+	}
+}
+
+// checkCapabilities reports each explicitly enabled option the frontend
+// generator does not support at the line that enables it
+func (d *decoder) checkCapabilities(frontend *types.FrontendConfig, set types.FieldSet, options map[types.ConfigField]*yaml.Node) {
+	capabilities, exists := d.registry.GetFrontendCapabilities(frontend.Framework)
+	if !exists {
+		return
+	}
+	for _, field := range types.GetConfigFields() {
+		node, ok := options[field]
+		if !ok {
+			continue
+		}
+		// Restrict one option at a time so each error points at its own line
+		probe := *frontend
+		if err := capabilities.Restrict(&probe, types.FieldSet{field: true}); err != nil {
+			d.errorf(node, "%v", err)
+		}
+	}
+}
+
+// joinNames formats a list of names for error messages
+func joinNames[T ~string](names []T) string {
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = string(name)
+	}
+	return strings.Join(parts, ", ")
+}
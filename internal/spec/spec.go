@@ -0,0 +1,123 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the spec format version written by Save
+const Version = 1
+
+// Document is the on-disk representation of a project spec
+type Document struct {
+	Version    int               `yaml:"version" json:"version"`
+	Name       string            `yaml:"name,omitempty" json:"name,omitempty"`
+	Path       string            `yaml:"path,omitempty" json:"path,omitempty"`
+	Type       string            `yaml:"type,omitempty" json:"type,omitempty"`
+	Backend    string            `yaml:"backend,omitempty" json:"backend,omitempty"`
+	ModulePath string            `yaml:"module-path,omitempty" json:"module-path,omitempty"`
+	Frontend   *FrontendDocument `yaml:"frontend,omitempty" json:"frontend,omitempty"`
+}
+
+// FrontendDocument is the on-disk representation of a frontend configuration
+type FrontendDocument struct {
+	Framework      string `yaml:"framework" json:"framework"`
+	TypeScript     bool   `yaml:"typescript" json:"typescript"`
+	TailwindCSS    bool   `yaml:"tailwind" json:"tailwind"`
+	ESLint         bool   `yaml:"eslint" json:"eslint"`
+	PackageManager string `yaml:"package-manager,omitempty" json:"package-manager,omitempty"`
+}
+
+// Load reads a YAML or JSON spec file and validates it against the registry.
+// It returns the configuration described by the file together with the set of
+// fields the file supplied; fields it leaves out are zero in the configuration.
+func Load(path string, registry types.FrameworkRegistry) (*types.ProjectConfig, types.FieldSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading spec file: %v", err)
+	}
+	return Parse(path, data, registry)
+}
+
+// Parse decodes spec data read from file; file is only used in error messages
+func Parse(file string, data []byte, registry types.FrameworkRegistry) (*types.ProjectConfig, types.FieldSet, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, &types.ConfigError{Message: fmt.Sprintf("%s: %v", file, err)}
+	}
+
+	d := &decoder{file: file, registry: registry}
+	config := &types.ProjectConfig{}
+	set := types.FieldSet{}
+
+	if len(root.Content) > 0 {
+		d.decodeProject(root.Content[0], config, set)
+	}
+	if len(d.errs) > 0 {
+		return nil, nil, d.errs
+	}
+
+	return config, set, nil
+}
+
+// Save writes config to path, as JSON when path ends in .json and YAML otherwise
+func Save(path string, config *types.ProjectConfig) error {
+	data, err := Marshal(config, strings.EqualFold(filepath.Ext(path), ".json"))
+	if err != nil {
+		return err
+	}
+	return utils.CreateFile(path, string(data))
+}
+
+// Marshal encodes config as a spec document in YAML or JSON
+func Marshal(config *types.ProjectConfig, asJSON bool) ([]byte, error) {
+	doc := NewDocument(config)
+	if asJSON {
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// NewDocument converts a project configuration to its spec representation
+func NewDocument(config *types.ProjectConfig) *Document {
+	doc := &Document{
+		Version:    Version,
+		Name:       config.Name,
+		Path:       config.Path,
+		Type:       string(config.Type),
+		Backend:    string(config.BackendFramework),
+		ModulePath: config.ModulePath,
+	}
+	if config.Frontend != nil {
+		doc.Frontend = &FrontendDocument{
+			Framework:      string(config.Frontend.Framework),
+			TypeScript:     config.Frontend.TypeScript,
+			TailwindCSS:    config.Frontend.TailwindCSS,
+			ESLint:         config.Frontend.ESLint,
+			PackageManager: string(config.Frontend.PackageManager),
+		}
+	}
+	return doc
+}
@@ -0,0 +1,199 @@
+package spec_test
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/verse91/fsgo-dev-kit/internal/generator"
+	"github.com/verse91/fsgo-dev-kit/internal/spec"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+func TestParse(t *testing.T) {
+	config, set, err := spec.Parse("fsgo.yaml", []byte(`version: 1
+name: shop
+type: web
+backend: gin
+module-path: github.com/acme/shop/server
+frontend:
+  framework: next
+  typescript: true
+  tailwind: false
+  package-manager: pnpm
+`), generator.NewGeneratorRegistry())
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	want := &types.ProjectConfig{
+		Name:             "shop",
+		Path:             "shop",
+		Type:             types.WebProject,
+		BackendFramework: types.Gin,
+		ModulePath:       "github.com/acme/shop/server",
+		Frontend: &types.FrontendConfig{
+			Framework:      types.NextJS,
+			TypeScript:     true,
+			PackageManager: types.Pnpm,
+		},
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("config = %+v, frontend %+v\nwant %+v, frontend %+v", config, config.Frontend, want, want.Frontend)
+	}
+	for _, field := range []types.ConfigField{types.FieldPath, types.FieldType, types.FieldBackend, types.FieldModulePath, types.FieldFrontend, types.FieldTypeScript, types.FieldTailwindCSS, types.FieldPackageManager} {
+		if !set[field] {
+			t.Errorf("field %v is not set", field)
+		}
+	}
+	if set[types.FieldESLint] {
+		t.Error("eslint is set but missing from the spec")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		// lines are the lines of the reported errors, in order
+		lines []int
+		// msgs are parts of the messages, one per error
+		msgs []string
+		err  error
+	}{
+		{
+			"unknown keys",
+			"name: shop\nbackends: gin\nfrontend:\n  framework: next\n  darkmode: true\n",
+			[]int{2, 5},
+			[]string{`unknown field "backends"`, `unknown frontend field "darkmode"`},
+			types.ErrInvalidConfig,
+		},
+		{
+			"wrong scalar types",
+			"name: [shop]\ntype: web\nfrontend:\n  framework: next\n  typescript: yes please\n  eslint: {}\n",
+			[]int{1, 5, 6},
+			[]string{"name must be a string", "typescript must be true or false", "eslint must be true or false"},
+			types.ErrInvalidConfig,
+		},
+		{
+			"not a mapping",
+			"name: shop\nfrontend: next\n",
+			[]int{2},
+			[]string{"frontend must be a mapping"},
+			types.ErrInvalidConfig,
+		},
+		{
+			"invalid enum values",
+			"version: 2\ntype: desktop\nfrontend:\n  framework: next\n  package-manager: deno\n",
+			[]int{1, 2, 5},
+			[]string{`unsupported spec version "2"`, `"desktop"`, `"deno"`},
+			types.ErrInvalidConfig,
+		},
+		{
+			"unknown framework",
+			"name: shop\n\nbackend: rails\n",
+			[]int{3},
+			[]string{"rails"},
+			types.ErrUnknownFramework,
+		},
+		{
+			"unsupported option",
+			"type: web\nfrontend:\n  framework: react\n  tailwind: true\n",
+			[]int{4},
+			[]string{"Tailwind CSS"},
+			types.ErrInvalidConfig,
+		},
+		{
+			"frontend of an api project",
+			"type: api\nbackend: gin\nfrontend:\n  framework: next\n",
+			[]int{4},
+			[]string{"frontend is only valid for"},
+			types.ErrInvalidConfig,
+		},
+		{
+			"invalid module path",
+			"name: shop\nmodule-path: bad//path\n",
+			[]int{2},
+			[]string{"bad//path"},
+			types.ErrInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := spec.Parse("fsgo.yaml", []byte(tt.spec), generator.NewGeneratorRegistry())
+			var errs spec.Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Parse error = %v, want spec.Errors", err)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Parse error = %v, want %v", err, tt.err)
+			}
+			if len(errs) != len(tt.lines) {
+				t.Fatalf("got %d errors, want %d:\n%v", len(errs), len(tt.lines), err)
+			}
+			for i, e := range errs {
+				if e.File != "fsgo.yaml" || e.Line != tt.lines[i] {
+					t.Errorf("error %d at %s:%d, want fsgo.yaml:%d", i, e.File, e.Line, tt.lines[i])
+				}
+				if !strings.Contains(e.Msg, tt.msgs[i]) {
+					t.Errorf("error %d = %q, want it to contain %q", i, e.Msg, tt.msgs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseInvalidYAML(t *testing.T) {
+	_, _, err := spec.Parse("fsgo.yaml", []byte("name: [shop\n"), generator.NewGeneratorRegistry())
+	if !errors.Is(err, types.ErrInvalidConfig) {
+		t.Errorf("Parse error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	configs := map[string]*types.ProjectConfig{
+		"web": {
+			Name:             "shop",
+			Path:             "apps/shop",
+			Type:             types.WebProject,
+			BackendFramework: types.Fiber,
+			ModulePath:       "github.com/acme/shop/server",
+			Frontend: &types.FrontendConfig{
+				Framework:      types.NextJS,
+				TypeScript:     true,
+				TailwindCSS:    true,
+				PackageManager: types.Bun,
+			},
+		},
+		"api": {
+			Name:             "api",
+			Path:             "api",
+			Type:             types.APIProject,
+			BackendFramework: types.Echo,
+		},
+	}
+
+	for name, config := range configs {
+		for _, ext := range []string{".yaml", ".json"} {
+			t.Run(name+ext, func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "fsgo"+ext)
+				if err := spec.Save(path, config); err != nil {
+					t.Fatalf("Save: %v", err)
+				}
+				loaded, _, err := spec.Load(path, generator.NewGeneratorRegistry())
+				if err != nil {
+					t.Fatalf("Load: %v", err)
+				}
+				if !reflect.DeepEqual(loaded, config) {
+					t.Errorf("loaded %+v, frontend %+v\nwant %+v, frontend %+v", loaded, loaded.Frontend, config, config.Frontend)
+				}
+				if doc := spec.NewDocument(loaded); !reflect.DeepEqual(doc, spec.NewDocument(config)) {
+					t.Errorf("document of the loaded config = %+v", doc)
+				}
+			})
+		}
+	}
+}
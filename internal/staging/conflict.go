@@ -0,0 +1,113 @@
+package staging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/pkg/fsys"
+)
+
+// NewSuffix is appended to the name of a generated file written next to an
+// existing one with ResolveWriteNew
+const NewSuffix = ".fsgo-new"
+
+// Resolution is how a conflict between a generated file and an existing file
+// is resolved
+type Resolution string
+
+// Conflict resolutions
+const (
+	// ResolveSkip keeps the existing file and drops the generated one
+	ResolveSkip Resolution = "skip"
+	// ResolveOverwrite replaces the existing file with the generated one
+	ResolveOverwrite Resolution = "overwrite"
+	// ResolveWriteNew keeps the existing file and writes the generated one
+	// next to it with NewSuffix
+	ResolveWriteNew Resolution = "new"
+	// ResolveFail aborts the generation
+	ResolveFail Resolution = "fail"
+)
+
+// Resolutions lists every resolution, in the order they are offered
+var Resolutions = []Resolution{ResolveSkip, ResolveOverwrite, ResolveWriteNew, ResolveFail}
+
+// ParseResolution parses the name of a resolution
+func ParseResolution(s string) (Resolution, error) {
+	for _, resolution := range Resolutions {
+		if strings.EqualFold(s, string(resolution)) {
+			return resolution, nil
+		}
+	}
+	names := make([]string, len(Resolutions))
+	for i, resolution := range Resolutions {
+		names[i] = string(resolution)
+	}
+	return "", fmt.Errorf("invalid conflict resolution %q (valid: %s)", s, strings.Join(names, ", "))
+}
+
+// ErrConflict is returned when a conflict is resolved with ResolveFail
+var ErrConflict = errors.New("file already exists")
+
+// Conflict is a generated file whose path already holds a different file in
+// the target directory
+type Conflict struct {
+	// Path is the slash-separated path relative to the target
+	Path      string
+	Existing  []byte
+	Generated []byte
+}
+
+// Resolver decides how to resolve a conflict
+type Resolver func(conflict Conflict) (Resolution, error)
+
+// Detect returns the files p writes that already exist in target with
+// different content, before anything is generated. Files created by the
+// plan's commands can't be known up front; they are resolved when the staging
+// area is committed.
+func Detect(target fsys.FS, p *plan.Plan) ([]Conflict, error) {
+	var conflicts []Conflict
+	for _, action := range p.Actions() {
+		if action.Kind != plan.KindWrite {
+			continue
+		}
+		existing, err := target.ReadFile(action.Path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			info, statErr := target.Stat(action.Path)
+			if statErr == nil && info.IsDir() {
+				return nil, fmt.Errorf("cannot write %s: a directory with that name already exists", action.Path)
+			}
+			// A parent that is a file fails the same way on disk; let the
+			// commit report it
+			continue
+		}
+		if !bytes.Equal(existing, action.Content) {
+			conflicts = append(conflicts, Conflict{Path: action.Path, Existing: existing, Generated: action.Content})
+		}
+	}
+	return conflicts, nil
+}
+
+// Decided returns a resolver answering with the resolution stored in
+// decisions for a path and asking next for any other path
+func Decided(decisions map[string]Resolution, next Resolver) Resolver {
+	return func(conflict Conflict) (Resolution, error) {
+		if resolution, exists := decisions[conflict.Path]; exists {
+			return resolution, nil
+		}
+		return next(conflict)
+	}
+}
+
+// Always returns a resolver that resolves every conflict with resolution
+func Always(resolution Resolution) Resolver {
+	return func(Conflict) (Resolution, error) {
+		return resolution, nil
+	}
+}
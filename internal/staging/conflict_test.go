@@ -0,0 +1,175 @@
+package staging_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/verse91/fsgo-dev-kit/internal/events"
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/runner"
+	"github.com/verse91/fsgo-dev-kit/internal/staging"
+	"github.com/verse91/fsgo-dev-kit/pkg/fsys"
+)
+
+// existingFiles are in the target before every conflict test
+var existingFiles = map[string]string{
+	"README.md":      "mine\n",
+	"server/main.go": "package main\n",
+	"notes.txt":      "notes\n",
+}
+
+// newConflictPlan returns a plan writing a changed README.md, an unchanged
+// server/main.go and a new Makefile
+func newConflictPlan() *plan.Plan {
+	p := plan.New()
+	p.WriteFile("README.md", "generated\n", plan.FileMode)
+	p.WriteFile("server/main.go", "package main\n", plan.FileMode)
+	p.WriteFile("Makefile", "run:\n", plan.FileMode)
+	return p
+}
+
+// generateInto applies p to a staging area for target and commits it with
+// resolve
+func generateInto(t *testing.T, target string, p *plan.Plan, resolve staging.Resolver) error {
+	t.Helper()
+	area, err := staging.New(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	area.SetResolver(resolve)
+
+	root, err := fsys.NewOS(area.Dir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor := &plan.Executor{Runner: runner.NewFake(), Events: events.NewJSON(io.Discard)}
+	if err := executor.Apply(context.Background(), p, root); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	err = area.Commit()
+	if err != nil {
+		// The failed area is kept for inspection; drop it so only the
+		// target's own files are compared
+		if removeErr := os.RemoveAll(area.Dir()); removeErr != nil {
+			t.Fatal(removeErr)
+		}
+	}
+	return err
+}
+
+func TestResolveConflicts(t *testing.T) {
+	tests := []struct {
+		resolution staging.Resolution
+		want       map[string]string
+		err        error
+	}{
+		{staging.ResolveSkip, map[string]string{
+			"README.md":      "mine\n",
+			"server/main.go": "package main\n",
+			"notes.txt":      "notes\n",
+			"Makefile":       "run:\n",
+		}, nil},
+		{staging.ResolveOverwrite, map[string]string{
+			"README.md":      "generated\n",
+			"server/main.go": "package main\n",
+			"notes.txt":      "notes\n",
+			"Makefile":       "run:\n",
+		}, nil},
+		{staging.ResolveWriteNew, map[string]string{
+			"README.md":                     "mine\n",
+			"README.md" + staging.NewSuffix: "generated\n",
+			"server/main.go":                "package main\n",
+			"notes.txt":                     "notes\n",
+			"Makefile":                      "run:\n",
+		}, nil},
+		{staging.ResolveFail, existingFiles, staging.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.resolution), func(t *testing.T) {
+			target := t.TempDir()
+			writeFiles(t, target, existingFiles)
+
+			var asked []string
+			resolve := func(conflict staging.Conflict) (staging.Resolution, error) {
+				asked = append(asked, conflict.Path)
+				return tt.resolution, nil
+			}
+			err := generateInto(t, target, newConflictPlan(), resolve)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Commit error = %v, want %v", err, tt.err)
+			}
+			assertFiles(t, target, tt.want)
+			// Unchanged files are never asked about
+			if !slices.Equal(asked, []string{"README.md"}) {
+				t.Errorf("asked about %q, want only README.md", asked)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	target := t.TempDir()
+	writeFiles(t, target, existingFiles)
+	root, err := fsys.NewOS(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conflicts, err := staging.Detect(fsys.NewReadOnly(root), newConflictPlan())
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %+v, want README.md only", conflicts)
+	}
+	conflict := conflicts[0]
+	if conflict.Path != "README.md" || string(conflict.Existing) != "mine\n" || string(conflict.Generated) != "generated\n" {
+		t.Errorf("conflict = %s %q %q", conflict.Path, conflict.Existing, conflict.Generated)
+	}
+
+	if err := os.Mkdir(filepath.Join(target, "Makefile"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := staging.Detect(root, newConflictPlan()); err == nil {
+		t.Error("Detect accepted a directory where a file is written")
+	}
+}
+
+func TestParseResolution(t *testing.T) {
+	for input, want := range map[string]staging.Resolution{
+		"skip":      staging.ResolveSkip,
+		"overwrite": staging.ResolveOverwrite,
+		"New":       staging.ResolveWriteNew,
+		"FAIL":      staging.ResolveFail,
+	} {
+		if got, err := staging.ParseResolution(input); err != nil || got != want {
+			t.Errorf("ParseResolution(%q) = %q, %v, want %q", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "merge", "skip "} {
+		if _, err := staging.ParseResolution(input); err == nil {
+			t.Errorf("ParseResolution(%q) succeeded", input)
+		}
+	}
+}
+
+func TestDecided(t *testing.T) {
+	resolve := staging.Decided(
+		map[string]staging.Resolution{"README.md": staging.ResolveSkip},
+		staging.Always(staging.ResolveOverwrite),
+	)
+	for path, want := range map[string]staging.Resolution{
+		"README.md": staging.ResolveSkip,
+		"Makefile":  staging.ResolveOverwrite,
+	} {
+		if got, err := resolve(staging.Conflict{Path: path}); err != nil || got != want {
+			t.Errorf("resolution of %s = %q, %v, want %q", path, got, err, want)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package staging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// dirPattern names staging directories; the random suffix keeps concurrent
+// runs apart
+const dirPattern = ".fsgo-staging-*"
+
+// backupDir is where files replaced in the target are kept, below the
+// staging directory, until the commit succeeds
+const backupDir = ".fsgo-backup"
+
+// Area is a staging directory a project is generated into before it is moved
+// into its target directory. It lives inside the target so moving files in
+// is a rename on the same filesystem.
+//
+// Existing files in the target are never replaced without a resolver saying
+// so, and only what the area moved into the target is removed on rollback,
+// so generating into a directory with existing files, such as ".", leaves
+// those files alone.
+type Area struct {
+	target  string
+	dir     string
+	resolve Resolver
+
+	// created are the target and its missing parents, outermost first
+	created []string
+	// moved are the paths, relative to target, moved in by Commit, in order
+	moved []string
+	// replaced are the paths, relative to target, of existing files that were
+	// moved to the backup directory to make room
+	replaced []string
+}
+
+// New creates a staging area for the target directory, creating the target
+// if it doesn't exist yet
+func New(target string) (*Area, error) {
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Area{target: target}
+	if err := a.createTarget(); err != nil {
+		a.removeCreated()
+		return nil, fmt.Errorf("error creating project directory: %v", err)
+	}
+
+	dir, err := os.MkdirTemp(target, dirPattern)
+	if err != nil {
+		a.removeCreated()
+		return nil, fmt.Errorf("error creating staging directory: %v", err)
+	}
+	a.dir = dir
+	return a, nil
+}
+
+// SetResolver sets how files that already exist in the target are resolved
+// on commit. Without a resolver the commit fails on the first such file.
+func (a *Area) SetResolver(resolve Resolver) {
+	a.resolve = resolve
+}
+
+// Dir returns the staging directory the project is generated into
+func (a *Area) Dir() string {
+	return a.dir
+}
+
+// Commit moves everything generated in the staging directory into the target
+// and removes the staging directory. If a move fails, everything moved so far
+// is rolled back and the staging directory is kept for inspection.
+func (a *Area) Commit() error {
+	if err := a.merge("."); err != nil {
+		if rollbackErr := a.rollbackMoves(); rollbackErr != nil {
+			err = errors.Join(err, rollbackErr)
+		}
+		return fmt.Errorf("error moving project into place: %w", err)
+	}
+
+	if err := os.RemoveAll(a.dir); err != nil {
+		return fmt.Errorf("error removing staging directory: %v", err)
+	}
+	return nil
+}
+
+// Discard removes the staging directory and the target directories New
+// created, after generation failed. With keep set the staging directory is
+// left in place for debugging.
+func (a *Area) Discard(keep bool) error {
+	if keep {
+		return nil
+	}
+	if err := os.RemoveAll(a.dir); err != nil {
+		return fmt.Errorf("error removing staging directory: %v", err)
+	}
+	a.removeCreated()
+	return nil
+}
+
+// createTarget creates the target and any missing parents, recording each
+// directory it creates
+func (a *Area) createTarget() error {
+	var missing []string
+	for dir := a.target; ; dir = filepath.Dir(dir) {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", dir)
+			}
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) || dir == filepath.Dir(dir) {
+			return err
+		}
+		missing = append(missing, dir)
+	}
+
+	for i := len(missing) - 1; i >= 0; i-- {
+		if err := os.Mkdir(missing[i], 0o755); err != nil {
+			return err
+		}
+		a.created = append(a.created, missing[i])
+	}
+	return nil
+}
+
+// removeCreated removes the directories createTarget created, innermost
+// first. Directories that are not empty are kept.
+func (a *Area) removeCreated() {
+	for i := len(a.created) - 1; i >= 0; i-- {
+		if err := os.Remove(a.created[i]); err != nil {
+			return
+		}
+	}
+	a.created = nil
+}
+
+// merge moves the entries of the staging directory rel into the same
+// directory of the target. Directories that already exist are merged
+// recursively. Existing files are resolved with the area's resolver and backed
+// up before being replaced.
+func (a *Area) merge(rel string) error {
+	entries, err := os.ReadDir(filepath.Join(a.dir, rel))
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := filepath.Join(rel, entry.Name())
+		if name == backupDir {
+			continue
+		}
+		src := filepath.Join(a.dir, name)
+		dst := filepath.Join(a.target, name)
+
+		existing, err := os.Lstat(dst)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return err
+		case existing.IsDir() && entry.IsDir():
+			if err := a.merge(name); err != nil {
+				return err
+			}
+			continue
+		case existing.IsDir() || entry.IsDir():
+			return fmt.Errorf("cannot replace %s: one is a directory and the other is not", dst)
+		default:
+			resolution, err := a.resolveConflict(name)
+			if err != nil {
+				return err
+			}
+			switch resolution {
+			case ResolveSkip:
+				continue
+			case ResolveWriteNew:
+				name += NewSuffix
+				dst += NewSuffix
+				if _, err := os.Lstat(dst); err == nil {
+					if err := a.backup(name); err != nil {
+						return err
+					}
+				}
+			default:
+				if err := a.backup(name); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := os.Rename(src, dst); err != nil {
+			return err
+		}
+		a.moved = append(a.moved, name)
+	}
+	return nil
+}
+
+// resolveConflict decides what happens to the staged file name, which already
+// exists in the target. Files with identical content are skipped without
+// asking.
+func (a *Area) resolveConflict(name string) (Resolution, error) {
+	existing, err := os.ReadFile(filepath.Join(a.target, name))
+	if err != nil {
+		return "", err
+	}
+	generated, err := os.ReadFile(filepath.Join(a.dir, name))
+	if err != nil {
+		return "", err
+	}
+	if bytes.Equal(existing, generated) {
+		return ResolveSkip, nil
+	}
+
+	resolution := ResolveFail
+	if a.resolve != nil {
+		conflict := Conflict{Path: filepath.ToSlash(name), Existing: existing, Generated: generated}
+		if resolution, err = a.resolve(conflict); err != nil {
+			return "", err
+		}
+	}
+	if resolution == ResolveFail {
+		return "", fmt.Errorf("%s: %w", filepath.Join(a.target, name), ErrConflict)
+	}
+	return resolution, nil
+}
+
+// backup moves the existing target file name into the backup directory
+func (a *Area) backup(name string) error {
+	backup := filepath.Join(a.dir, backupDir, name)
+	if err := os.MkdirAll(filepath.Dir(backup), 0o755); err != nil {
+		return err
+	}
+	if err := os.Rename(filepath.Join(a.target, name), backup); err != nil {
+		return err
+	}
+	a.replaced = append(a.replaced, name)
+	return nil
+}
+
+// rollbackMoves removes everything Commit moved into the target and puts
+// back the files it replaced
+func (a *Area) rollbackMoves() error {
+	var errs []error
+	for i := len(a.moved) - 1; i >= 0; i-- {
+		if err := os.RemoveAll(filepath.Join(a.target, a.moved[i])); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, name := range a.replaced {
+		if err := os.Rename(filepath.Join(a.dir, backupDir, name), filepath.Join(a.target, name)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	a.moved, a.replaced = nil, nil
+	return errors.Join(errs...)
+}
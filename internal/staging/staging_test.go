@@ -0,0 +1,214 @@
+package staging_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/verse91/fsgo-dev-kit/internal/staging"
+)
+
+// writeFiles writes files, keyed by slash-separated path, below dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// readFiles returns the content of every file below dir, keyed by
+// slash-separated path
+func readFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+// assertFiles fails t unless the files below dir are exactly want
+func assertFiles(t *testing.T, dir string, want map[string]string) {
+	t.Helper()
+	got := readFiles(t, dir)
+	for name, content := range want {
+		if got[name] != content {
+			t.Errorf("%s = %q, want %q", name, got[name], content)
+		}
+	}
+	for name := range got {
+		if _, ok := want[name]; !ok {
+			t.Errorf("unexpected file %s", name)
+		}
+	}
+}
+
+func TestCommitMergesIntoExistingDir(t *testing.T) {
+	target := t.TempDir()
+	writeFiles(t, target, map[string]string{
+		"README.md":          "mine\n",
+		"server/notes.txt":   "notes\n",
+		"server/go.mod":      "module shop\n",
+		"client/src/app.tsx": "app\n",
+	})
+
+	area, err := staging.New(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	area.SetResolver(staging.Always(staging.ResolveOverwrite))
+	writeFiles(t, area.Dir(), map[string]string{
+		"README.md":      "generated\n",
+		"server/go.mod":  "module shop\n",
+		"server/main.go": "package main\n",
+		"Makefile":       "run:\n",
+	})
+
+	if err := area.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	assertFiles(t, target, map[string]string{
+		"README.md":          "generated\n",
+		"Makefile":           "run:\n",
+		"server/notes.txt":   "notes\n",
+		"server/go.mod":      "module shop\n",
+		"server/main.go":     "package main\n",
+		"client/src/app.tsx": "app\n",
+	})
+	if _, err := os.Stat(area.Dir()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("staging directory is left after commit: %v", err)
+	}
+}
+
+func TestCommitRollsBack(t *testing.T) {
+	target := t.TempDir()
+	existing := map[string]string{
+		"README.md":     "mine\n",
+		"server/go.mod": "module mine\n",
+		// A directory where a file is generated fails the move after the
+		// files above were replaced
+		"zz/keep.txt": "keep\n",
+	}
+	writeFiles(t, target, existing)
+
+	area, err := staging.New(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	area.SetResolver(staging.Always(staging.ResolveOverwrite))
+	writeFiles(t, area.Dir(), map[string]string{
+		"Makefile":       "run:\n",
+		"README.md":      "generated\n",
+		"server/go.mod":  "module shop\n",
+		"server/main.go": "package main\n",
+		"zz":             "file\n",
+	})
+
+	if err := area.Commit(); err == nil {
+		t.Fatal("Commit succeeded")
+	}
+	staged := area.Dir()
+	if _, err := os.Stat(staged); err != nil {
+		t.Fatalf("staging directory was removed after a failed commit: %v", err)
+	}
+	if err := os.RemoveAll(staged); err != nil {
+		t.Fatal(err)
+	}
+	assertFiles(t, target, existing)
+}
+
+func TestCommitConflict(t *testing.T) {
+	target := t.TempDir()
+	writeFiles(t, target, map[string]string{"README.md": "mine\n"})
+
+	area, err := staging.New(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, area.Dir(), map[string]string{"README.md": "generated\n"})
+	if err := area.Commit(); !errors.Is(err, staging.ErrConflict) {
+		t.Errorf("Commit error = %v, want ErrConflict", err)
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	parent := t.TempDir()
+	writeFiles(t, parent, map[string]string{"existing/keep.txt": "keep\n"})
+
+	tests := []struct {
+		name    string
+		target  string
+		removed string
+		kept    string
+	}{
+		{"missing parents", "a/b/shop", "a", ""},
+		{"existing parent", "existing/shop", "existing/shop", "existing/keep.txt"},
+		{"existing target", "existing", "", "existing/keep.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			area, err := staging.New(filepath.Join(parent, tt.target))
+			if err != nil {
+				t.Fatal(err)
+			}
+			writeFiles(t, area.Dir(), map[string]string{"main.go": "package main\n"})
+
+			if err := area.Discard(false); err != nil {
+				t.Fatalf("Discard: %v", err)
+			}
+			if _, err := os.Stat(area.Dir()); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("staging directory is left: %v", err)
+			}
+			if tt.removed != "" {
+				if _, err := os.Stat(filepath.Join(parent, tt.removed)); !errors.Is(err, os.ErrNotExist) {
+					t.Errorf("%s is left: %v", tt.removed, err)
+				}
+			}
+			if tt.kept != "" {
+				if _, err := os.Stat(filepath.Join(parent, tt.kept)); err != nil {
+					t.Errorf("%s was removed: %v", tt.kept, err)
+				}
+			}
+		})
+	}
+}
+
+func TestDiscardKeep(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "shop")
+	area, err := staging.New(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, area.Dir(), map[string]string{"main.go": "package main\n"})
+
+	if err := area.Discard(true); err != nil {
+		t.Fatalf("Discard: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(area.Dir(), "main.go"))
+	if err != nil || string(content) != "package main\n" {
+		t.Errorf("kept main.go = %q, %v", content, err)
+	}
+}
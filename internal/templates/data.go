@@ -0,0 +1,116 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/pkg/utils"
+)
+
+// BackendPort is the port the generated server listens on by default
+const BackendPort = 8080
+
+// Data is what templates are rendered against: the choices of a project
+// configuration along with the values derived from them
+type Data struct {
+	// Name is the project name, e.g. my-app
+	Name string
+	// AppName is the project name for people to read, e.g. My App
+	AppName string
+	// ModulePath is the Go module path of the server, e.g.
+	// github.com/acme/my-app/server
+	ModulePath string
+	Backend    BackendData
+	// Frontend is nil for API-only projects
+	Frontend *FrontendData
+}
+
+// BackendData describes the generated server
+type BackendData struct {
+	// Framework is the display name of the backend framework, e.g. Gin
+	Framework string
+	Port      int
+	// Logger is the logging library of the server, e.g. Zap; empty when it
+	// uses the standard library's log package
+	Logger string
+	// HotReload is set when the server is configured to run with Air
+	HotReload bool
+	// DBCheck is set when the server has a command testing the database
+	// connection in server/cmd/test
+	DBCheck bool
+}
+
+// URL is where the server can be reached during development
+func (b BackendData) URL() string {
+	return fmt.Sprintf("http://localhost:%d", b.Port)
+}
+
+// FrontendData describes the generated client
+type FrontendData struct {
+	// Framework is the display name of the frontend framework, e.g. Next.js
+	Framework      string
+	TypeScript     bool
+	TailwindCSS    bool
+	ESLint         bool
+	PackageManager string
+	// Port is the port of the framework's development server
+	Port int
+	// EnvPrefix is the prefix of environment variables exposed to the browser
+	EnvPrefix string
+	// DevScript is the package script starting the development server
+	DevScript string
+}
+
+// URL is where the client can be reached during development
+func (f FrontendData) URL() string {
+	return fmt.Sprintf("http://localhost:%d", f.Port)
+}
+
+// backendDefaults describe the tooling of the servers of backend frameworks
+// that go beyond a single main package
+var backendDefaults = map[types.BackendFramework]BackendData{
+	types.Fiber: {Logger: "Zap", HotReload: true, DBCheck: true},
+}
+
+// frontendDefaults are the development settings of each frontend framework
+// that differ from those of Next.js
+var frontendDefaults = map[types.FrontendFramework]FrontendData{
+	types.NextJS: {Port: 3000, EnvPrefix: "NEXT_PUBLIC_", DevScript: "dev"},
+	types.React:  {Port: 3000, EnvPrefix: "REACT_APP_", DevScript: "start"},
+	types.Svelte: {Port: 5173, EnvPrefix: "VITE_", DevScript: "dev"},
+}
+
+// NewData returns the data of the project configured by config. Frontends
+// that are not built in take their development settings from the library
+// carried by ctx.
+func NewData(ctx context.Context, config *types.ProjectConfig) *Data {
+	data := &Data{
+		Name:       config.Name,
+		AppName:    title(config.Name),
+		ModulePath: config.ModulePath,
+	}
+
+	data.Backend = backendDefaults[config.BackendFramework]
+	data.Backend.Framework = string(config.BackendFramework)
+	data.Backend.Port = BackendPort
+
+	if data.ModulePath == "" {
+		data.ModulePath = utils.ModulePathFromName(config.Name)
+	}
+
+	if config.Type == types.WebProject && config.Frontend != nil {
+		frontend := FromContext(ctx).frontend(config.Frontend.Framework)
+		frontend.Framework = string(config.Frontend.Framework)
+		frontend.TypeScript = config.Frontend.TypeScript
+		frontend.TailwindCSS = config.Frontend.TailwindCSS
+		frontend.ESLint = config.Frontend.ESLint
+		frontend.PackageManager = string(config.Frontend.PackageManager)
+		if frontend.PackageManager == "" {
+			frontend.PackageManager = string(types.Npm)
+		}
+		data.Frontend = &frontend
+	}
+
+	return data
+}
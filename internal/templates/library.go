@@ -0,0 +1,226 @@
+package templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+// Library looks up template sets in override directories before the built-in
+// ones. Overrides apply path by path: a file in an override directory
+// replaces the built-in template of the same path in the same set, and every
+// other template of the set still comes from the built-in one. An override
+// directory holds one directory per set, laid out like the output of
+// 'fsgo templates export'.
+type Library struct {
+	// layers are searched in order; the built-in templates come last
+	layers []fs.FS
+	// frontends holds the development settings of the frontends registered
+	// with RegisterFrontend
+	frontends map[types.FrontendFramework]FrontendData
+}
+
+// NewLibrary returns a library looking up templates in the override
+// directories dirs, in order, before the built-in ones
+func NewLibrary(dirs ...string) *Library {
+	library := &Library{}
+	for _, dir := range dirs {
+		library.layers = append(library.layers, os.DirFS(dir))
+	}
+	builtin, _ := fs.Sub(embedded, "files")
+	library.layers = append(library.layers, builtin)
+	return library
+}
+
+// Builtin returns a library with only the built-in templates
+func Builtin() *Library {
+	return NewLibrary()
+}
+
+// Sets returns the names of the sets in the library, sorted
+func (l *Library) Sets() []string {
+	var names []string
+	for _, layer := range l.layers {
+		entries, err := fs.ReadDir(layer, ".")
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() && !slices.Contains(names, entry.Name()) {
+				names = append(names, entry.Name())
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load returns the set name, combining its templates and manifests from every
+// layer of the library that has them
+func (l *Library) Load(name string) (*Set, error) {
+	var layers []fs.FS
+	for _, layer := range l.layers {
+		if info, err := fs.Stat(layer, name); err == nil && info.IsDir() {
+			sub, err := fs.Sub(layer, name)
+			if err != nil {
+				return nil, err
+			}
+			layers = append(layers, sub)
+		}
+	}
+	if len(layers) == 0 || !fs.ValidPath(name) || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("no template set %q (available: %s)", name, strings.Join(l.Sets(), ", "))
+	}
+	return newSet(name, layers)
+}
+
+// RegisterFrontend sets the development settings of framework for projects
+// generated with the library, for frontends that are not built in such as
+// those of template packs. Its Framework and option fields are ignored. It
+// must not be called while the library is in use.
+func (l *Library) RegisterFrontend(framework types.FrontendFramework, settings FrontendData) {
+	if l.frontends == nil {
+		l.frontends = map[types.FrontendFramework]FrontendData{}
+	}
+	l.frontends[framework] = FrontendData{
+		Port:      settings.Port,
+		EnvPrefix: settings.EnvPrefix,
+		DevScript: settings.DevScript,
+	}
+}
+
+// frontend returns the development settings of framework: the registered
+// ones, the built-in ones or else those of Next.js
+func (l *Library) frontend(framework types.FrontendFramework) FrontendData {
+	if settings, ok := l.frontends[framework]; ok {
+		return settings
+	}
+	if settings, ok := frontendDefaults[framework]; ok {
+		return settings
+	}
+	return frontendDefaults[types.NextJS]
+}
+
+// libraryKey is the context key of the library generators load sets from
+type libraryKey struct{}
+
+// NewContext returns a context carrying library, so that PlanSet loads template
+// sets from it
+func NewContext(ctx context.Context, library *Library) context.Context {
+	return context.WithValue(ctx, libraryKey{}, library)
+}
+
+// FromContext returns the library carried by ctx, or the built-in templates
+func FromContext(ctx context.Context) *Library {
+	if library, ok := ctx.Value(libraryKey{}).(*Library); ok {
+		return library
+	}
+	return Builtin()
+}
+
+// PlanSet adds the template set name from the library carried by ctx to p, as
+// Set.Plan does
+func PlanSet(ctx context.Context, p *plan.Plan, name string, data *Data) error {
+	set, err := FromContext(ctx).Load(name)
+	if err != nil {
+		return err
+	}
+	return set.Plan(p, data)
+}
+
+// Export writes the built-in template set name to dir/name, ready to be
+// customised as overrides. Unless overwrite is set nothing is written if any
+// of the files already exists. It returns the paths of the files written.
+func Export(name, dir string, overwrite bool) ([]string, error) {
+	set, err := Builtin().Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	err = fs.WalkDir(set.fsys, ".", func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		target := filepath.Join(dir, name, filepath.FromSlash(file))
+		if _, err := os.Stat(target); err == nil && !overwrite {
+			return fmt.Errorf("%s already exists (pass --force to overwrite it)", target)
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error exporting template set %s: %w", name, err)
+	}
+
+	var written []string
+	for _, file := range files {
+		target := filepath.Join(dir, name, filepath.FromSlash(file))
+		content, err := fs.ReadFile(set.fsys, file)
+		if err == nil {
+			err = os.MkdirAll(filepath.Dir(target), 0o755)
+		}
+		if err == nil {
+			err = os.WriteFile(target, content, 0o644)
+		}
+		if err != nil {
+			return written, fmt.Errorf("error exporting template set %s: %w", name, err)
+		}
+		written = append(written, target)
+	}
+	return written, nil
+}
+
+// overlay is a filesystem combining layers: a file is read from the first
+// layer that has it and directories list the entries of every layer
+type overlay []fs.FS
+
+// Open opens name in the first layer that has it
+func (o overlay) Open(name string) (fs.File, error) {
+	for _, layer := range o {
+		file, err := layer.Open(name)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir lists the entries of the directory name in every layer, sorted by
+// name. An entry is taken from the first layer that has it.
+func (o overlay) ReadDir(name string) ([]fs.DirEntry, error) {
+	var entries []fs.DirEntry
+	found := false
+	for _, layer := range o {
+		layerEntries, err := fs.ReadDir(layer, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		for _, entry := range layerEntries {
+			if !slices.ContainsFunc(entries, func(e fs.DirEntry) bool { return e.Name() == entry.Name() }) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
@@ -0,0 +1,126 @@
+package templates
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Funcs are the helper functions available to every template
+var Funcs = template.FuncMap{
+	"kebab":  kebab,
+	"snake":  snake,
+	"camel":  camel,
+	"pascal": pascal,
+	"title":  title,
+	"upper":  strings.ToUpper,
+	"lower":  strings.ToLower,
+	"quote":  strconv.Quote,
+	"squote": squote,
+}
+
+// Render executes the template text against data. name identifies the
+// template in errors.
+func Render(name, text string, data *Data) (string, error) {
+	tmpl, err := template.New(name).Funcs(Funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("error parsing template %s: %w", name, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("error rendering template %s: %w", name, err)
+	}
+	return b.String(), nil
+}
+
+// words splits s into lowercase words at separators, case changes and
+// between letters and digits, so that "myApp", "my-app" and "My App" all
+// give "my" and "app"
+func words(s string) []string {
+	var result []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			result = append(result, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if i > 0 && len(current) > 0 {
+			prev := runes[i-1]
+			switch {
+			case unicode.IsUpper(r) && unicode.IsLower(prev):
+				flush()
+			case unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+				// The last capital of an acronym starts the next word, as in APIServer
+				flush()
+			case unicode.IsDigit(r) != unicode.IsDigit(prev):
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return result
+}
+
+// capitalize upper-cases the first letter of word
+func capitalize(word string) string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return word
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// kebab returns s as my-app
+func kebab(s string) string {
+	return strings.Join(words(s), "-")
+}
+
+// snake returns s as my_app
+func snake(s string) string {
+	return strings.Join(words(s), "_")
+}
+
+// camel returns s as myApp
+func camel(s string) string {
+	parts := words(s)
+	for i := 1; i < len(parts); i++ {
+		parts[i] = capitalize(parts[i])
+	}
+	return strings.Join(parts, "")
+}
+
+// pascal returns s as MyApp
+func pascal(s string) string {
+	parts := words(s)
+	for i := range parts {
+		parts[i] = capitalize(parts[i])
+	}
+	return strings.Join(parts, "")
+}
+
+// title returns s as My App
+func title(s string) string {
+	parts := words(s)
+	for i := range parts {
+		parts[i] = capitalize(parts[i])
+	}
+	return strings.Join(parts, " ")
+}
+
+// squote returns s as a single-quoted JavaScript string
+func squote(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
+	return "'" + replacer.Replace(s) + "'"
+}
@@ -0,0 +1,218 @@
+package templates
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+)
+
+// ManifestName is the name of the optional manifest at the top of a set
+const ManifestName = "manifest.yaml"
+
+// Ext marks the files of a set that are rendered as templates. It is removed
+// from their names; other files are copied as they are.
+const Ext = ".tmpl"
+
+// embedded holds the built-in template sets, one directory each
+//
+//go:embed all:files
+var embedded embed.FS
+
+// Set is a directory of templates generating part of a project, such as a
+// backend. Its layout mirrors the project: the template server/main.go.tmpl
+// writes server/main.go. The path of a template may contain actions too, and
+// a template is left out when its rendered path has an empty element, so
+// server/{{if .Frontend}}cors.go{{end}}.tmpl is only written for web projects.
+type Set struct {
+	Name     string
+	fsys     fs.FS
+	manifest Manifest
+}
+
+// Manifest configures a set. It is read from the set's manifest.yaml.
+type Manifest struct {
+	// Dirs are created even if no template writes a file into them. They may
+	// contain template actions like the paths of templates.
+	Dirs []string `yaml:"dirs"`
+	// Files configures templates, keyed by their path in the set
+	Files map[string]FileOptions `yaml:"files"`
+}
+
+// FileOptions configures a single template
+type FileOptions struct {
+	// Mode is the octal permission of the file, e.g. "0755" for scripts. It
+	// defaults to plan.FileMode.
+	Mode string `yaml:"mode"`
+	// When is a template pipeline such as .Frontend.TailwindCSS; the file is
+	// only written when it is true in the sense of text/template's if
+	When string `yaml:"when"`
+}
+
+// File is a rendered template
+type File struct {
+	// Path is the slash-separated path of the file in the project
+	Path    string
+	Content string
+	Mode    fs.FileMode
+}
+
+// newSet returns the set name combining layers, as the overlay of their
+// files, and merges their manifests: directories of every layer are created,
+// and the options of a template come from the first layer that configures it
+func newSet(name string, layers []fs.FS) (*Set, error) {
+	set := &Set{Name: name, fsys: overlay(layers)}
+	for _, layer := range layers {
+		manifest, err := readManifest(layer)
+		if err != nil {
+			return nil, fmt.Errorf("error reading manifest of template set %s: %w", name, err)
+		}
+		for _, dir := range manifest.Dirs {
+			if !slices.Contains(set.manifest.Dirs, dir) {
+				set.manifest.Dirs = append(set.manifest.Dirs, dir)
+			}
+		}
+		for file, options := range manifest.Files {
+			if _, exists := set.manifest.Files[file]; exists {
+				continue
+			}
+			if set.manifest.Files == nil {
+				set.manifest.Files = make(map[string]FileOptions)
+			}
+			set.manifest.Files[file] = options
+		}
+	}
+	return set, nil
+}
+
+// readManifest reads the manifest of a layer of a set, if it has one
+func readManifest(layer fs.FS) (Manifest, error) {
+	var manifest Manifest
+	file, err := layer.Open(ManifestName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return manifest, err
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&manifest); err != nil && err != io.EOF {
+		return manifest, err
+	}
+	return manifest, nil
+}
+
+// Files renders the templates of the set against data, in the order of their
+// paths in the set. Templates whose path renders with an empty element or
+// whose When is false are left out.
+func (s *Set) Files(data *Data) ([]File, error) {
+	var files []File
+	err := fs.WalkDir(s.fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || name == ManifestName {
+			return nil
+		}
+
+		options := s.manifest.Files[name]
+		if options.When != "" {
+			included, err := s.render(name+" (when)", "{{if "+options.When+"}}true{{end}}", data)
+			if err != nil {
+				return err
+			}
+			if included != "true" {
+				return nil
+			}
+		}
+
+		target, ok, err := s.renderPath(name, strings.TrimSuffix(name, Ext), data)
+		if err != nil || !ok {
+			return err
+		}
+		mode := plan.FileMode
+		if options.Mode != "" {
+			parsed, err := strconv.ParseUint(options.Mode, 8, 32)
+			if err != nil {
+				return fmt.Errorf("invalid mode %q of %s in template set %s", options.Mode, name, s.Name)
+			}
+			mode = fs.FileMode(parsed)
+		}
+
+		content, err := fs.ReadFile(s.fsys, name)
+		if err != nil {
+			return err
+		}
+		file := File{Path: target, Content: string(content), Mode: mode}
+		if strings.HasSuffix(name, Ext) {
+			if file.Content, err = s.render(name, file.Content, data); err != nil {
+				return err
+			}
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// Plan adds the directories of the manifest and the rendered templates of the
+// set to p, with their paths relative to p
+func (s *Set) Plan(p *plan.Plan, data *Data) error {
+	for _, dir := range s.manifest.Dirs {
+		target, ok, err := s.renderPath(dir, dir, data)
+		if err != nil {
+			return err
+		}
+		if ok {
+			p.Mkdir(target, plan.DirMode)
+		}
+	}
+
+	files, err := s.Files(data)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		p.WriteFile(file.Path, file.Content, file.Mode)
+	}
+	return nil
+}
+
+// renderPath renders the path of the template name. It reports false if an
+// element of the rendered path is empty, and fails if it leaves the project.
+func (s *Set) renderPath(name, text string, data *Data) (string, bool, error) {
+	rendered, err := s.render(name+" (path)", text, data)
+	if err != nil {
+		return "", false, err
+	}
+	for _, element := range strings.Split(rendered, "/") {
+		if element == "" {
+			return "", false, nil
+		}
+	}
+	if !fs.ValidPath(rendered) || rendered == "." {
+		return "", false, fmt.Errorf("template %s in set %s renders to invalid path %q", name, s.Name, rendered)
+	}
+	return rendered, true, nil
+}
+
+// render executes text against data, naming the template by its path in the
+// set in errors
+func (s *Set) render(name, text string, data *Data) (string, error) {
+	return Render(path.Join(s.Name, name), text, data)
+}
@@ -0,0 +1,206 @@
+package templates_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/verse91/fsgo-dev-kit/internal/plan"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+// acmeSet uses every feature of a manifest and of template paths
+var acmeSet = map[string]string{
+	"acme/manifest.yaml": `dirs:
+  - server/tmp
+  - "{{if .Frontend}}client/public{{end}}"
+files:
+  scripts/dev.sh.tmpl:
+    mode: "0755"
+  client/tailwind.config.js:
+    when: and .Frontend .Frontend.TailwindCSS
+`,
+	"acme/README.md.tmpl":                                "# {{.AppName}}\n",
+	"acme/scripts/dev.sh.tmpl":                           "#!/bin/sh\necho {{.Name}}\n",
+	"acme/client/tailwind.config.js":                     "module.exports = {{.NotRendered}}\n",
+	"acme/server/{{if .Frontend}}cors.go{{end}}.tmpl":    "package server // {{.Frontend.URL}}\n",
+	"acme/server/{{.Backend.Framework | lower}}.go.tmpl": "package server\n",
+}
+
+// writeFiles writes files, keyed by slash-separated path, below dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// planSet plans the set name from a library with the override directory dir
+// for config and returns the directories and files it creates, by path
+func planSet(t *testing.T, dir, name string, config *types.ProjectConfig) ([]string, map[string]plan.Action) {
+	t.Helper()
+	ctx := templates.NewContext(context.Background(), templates.NewLibrary(dir))
+	p := plan.New()
+	if err := templates.PlanSet(ctx, p, name, templates.NewData(ctx, config)); err != nil {
+		t.Fatalf("PlanSet: %v", err)
+	}
+
+	var dirs []string
+	files := map[string]plan.Action{}
+	for _, action := range p.Actions() {
+		switch action.Kind {
+		case plan.KindMkdir:
+			dirs = append(dirs, action.Path)
+		case plan.KindWrite:
+			files[action.Path] = action
+		}
+	}
+	return dirs, files
+}
+
+// paths returns the sorted keys of files
+func paths(files map[string]plan.Action) []string {
+	var keys []string
+	for key := range files {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// webConfig returns a web project with a Svelte frontend
+func webConfig(tailwind bool) *types.ProjectConfig {
+	return &types.ProjectConfig{
+		Name:             "my-shop",
+		Path:             "my-shop",
+		Type:             types.WebProject,
+		BackendFramework: types.Gin,
+		Frontend:         &types.FrontendConfig{Framework: types.Svelte, TailwindCSS: tailwind, PackageManager: types.Npm},
+	}
+}
+
+func TestSetManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, acmeSet)
+
+	tests := []struct {
+		name   string
+		config *types.ProjectConfig
+		dirs   []string
+		files  []string
+	}{
+		{
+			"web with tailwind",
+			webConfig(true),
+			[]string{"server/tmp", "client/public"},
+			[]string{"README.md", "client/tailwind.config.js", "scripts/dev.sh", "server/cors.go", "server/gin.go"},
+		},
+		{
+			"web without tailwind",
+			webConfig(false),
+			[]string{"server/tmp", "client/public"},
+			[]string{"README.md", "scripts/dev.sh", "server/cors.go", "server/gin.go"},
+		},
+		{
+			"api",
+			&types.ProjectConfig{Name: "my-shop", Path: "my-shop", Type: types.APIProject, BackendFramework: types.Echo},
+			[]string{"server/tmp"},
+			[]string{"README.md", "scripts/dev.sh", "server/echo.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dirs, files := planSet(t, dir, "acme", tt.config)
+			if !slices.Equal(dirs, tt.dirs) {
+				t.Errorf("dirs = %q, want %q", dirs, tt.dirs)
+			}
+			if got := paths(files); !slices.Equal(got, tt.files) {
+				t.Errorf("files = %q, want %q", got, tt.files)
+			}
+
+			script := files["scripts/dev.sh"]
+			if script.Mode != 0o755 {
+				t.Errorf("scripts/dev.sh has mode %04o, want 0755", script.Mode)
+			}
+			if string(script.Content) != "#!/bin/sh\necho my-shop\n" {
+				t.Errorf("scripts/dev.sh = %q", script.Content)
+			}
+			if readme := files["README.md"]; readme.Mode != plan.FileMode || string(readme.Content) != "# My Shop\n" {
+				t.Errorf("README.md = %q with mode %04o", readme.Content, readme.Mode)
+			}
+			if config, ok := files["client/tailwind.config.js"]; ok && !strings.Contains(string(config.Content), "{{.NotRendered}}") {
+				t.Errorf("file without %s was rendered: %q", templates.Ext, config.Content)
+			}
+			if cors, ok := files["server/cors.go"]; ok && string(cors.Content) != "package server // http://localhost:5173\n" {
+				t.Errorf("server/cors.go = %q", cors.Content)
+			}
+		})
+	}
+}
+
+func TestSetErrors(t *testing.T) {
+	for name, files := range map[string]map[string]string{
+		"invalid mode": {
+			"bad/manifest.yaml": "files:\n  run.sh:\n    mode: rwx\n",
+			"bad/run.sh":        "#!/bin/sh\n",
+		},
+		"unknown manifest field": {
+			"bad/manifest.yaml": "directories: [server]\n",
+			"bad/README.md":     "bad\n",
+		},
+		"path leaving the project": {
+			"bad/{{`..`}}/escape.txt.tmpl": "escape\n",
+		},
+		"failing when": {
+			"bad/manifest.yaml": "files:\n  README.md:\n    when: .Frontend.TailwindCSS\n",
+			"bad/README.md":     "bad\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, files)
+			ctx := templates.NewContext(context.Background(), templates.NewLibrary(dir))
+			config := &types.ProjectConfig{Name: "shop", Path: "shop", Type: types.APIProject, BackendFramework: types.Gin}
+			if err := templates.PlanSet(ctx, plan.New(), "bad", templates.NewData(ctx, config)); err == nil {
+				t.Error("PlanSet succeeded")
+			}
+		})
+	}
+}
+
+func TestOverrideMergesManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"fiber/manifest.yaml":                "dirs:\n  - server/extra\nfiles:\n  server/extra.sh:\n    mode: \"0755\"\n",
+		"fiber/server/extra.sh":              "#!/bin/sh\n",
+		"fiber/server/pkg/utils/env.go.tmpl": "package utils // overridden\n",
+	})
+	config := &types.ProjectConfig{Name: "shop", Path: "shop", Type: types.APIProject, BackendFramework: types.Fiber}
+	dirs, files := planSet(t, dir, "fiber", config)
+
+	for _, want := range []string{"server/extra", "server/internal/config"} {
+		if !slices.Contains(dirs, want) {
+			t.Errorf("dirs %q do not include %s", dirs, want)
+		}
+	}
+	if files["server/extra.sh"].Mode != 0o755 {
+		t.Errorf("server/extra.sh has mode %04o, want 0755", files["server/extra.sh"].Mode)
+	}
+	if got := string(files["server/pkg/utils/env.go"].Content); got != "package utils // overridden\n" {
+		t.Errorf("overridden server/pkg/utils/env.go = %q", got)
+	}
+	if _, ok := files["server/cmd/server/main.go"]; !ok {
+		t.Error("built-in server/cmd/server/main.go is missing from the overridden set")
+	}
+}
@@ -0,0 +1,86 @@
+package tree
+
+import (
+	"sort"
+	"strings"
+)
+
+// treeNode is a directory or file in a rendered tree
+type treeNode struct {
+	name     string
+	path     string
+	dir      bool
+	children map[string]*treeNode
+}
+
+// Render draws paths as a directory tree below root. Paths are relative and
+// use forward slashes; a trailing slash marks a directory. When annotate is
+// not nil, its result for a path is appended to that path's line.
+func Render(root string, paths []string, annotate func(path string) string) string {
+	tree := &treeNode{name: root, dir: true, children: map[string]*treeNode{}}
+	for _, path := range paths {
+		node := tree
+		prefix := ""
+		parts := strings.Split(strings.TrimSuffix(path, "/"), "/")
+		for i, part := range parts {
+			child, exists := node.children[part]
+			if !exists {
+				child = &treeNode{name: part, path: prefix + part, children: map[string]*treeNode{}}
+				node.children[part] = child
+			}
+			// Anything with children, or listed with a trailing slash, is a directory
+			if i < len(parts)-1 || strings.HasSuffix(path, "/") {
+				child.dir = true
+			}
+			node = child
+			prefix += part + "/"
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(tree.label() + "\n")
+	tree.render(&b, "", annotate)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// render writes the children of n, each line starting with prefix
+func (n *treeNode) render(b *strings.Builder, prefix string, annotate func(string) string) {
+	children := n.sortedChildren()
+	for i, child := range children {
+		branch, indent := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		line := prefix + branch + child.label()
+		if annotate != nil {
+			if note := annotate(child.path); note != "" {
+				line += " " + note
+			}
+		}
+		b.WriteString(line + "\n")
+		child.render(b, prefix+indent, annotate)
+	}
+}
+
+// sortedChildren returns directories first, then files, each alphabetically
+func (n *treeNode) sortedChildren() []*treeNode {
+	children := make([]*treeNode, 0, len(n.children))
+	for _, child := range n.children {
+		children = append(children, child)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].dir != children[j].dir {
+			return children[i].dir
+		}
+		return children[i].name < children[j].name
+	})
+	return children
+}
+
+// label returns the node name, with a trailing slash for directories
+func (n *treeNode) label() string {
+	if n.dir {
+		return n.name + "/"
+	}
+	return n.name
+}
@@ -0,0 +1,693 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/verse91/fsgo-dev-kit/internal/spec"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
+	"github.com/verse91/fsgo-dev-kit/internal/tree"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/internal/userconfig"
+	"github.com/verse91/fsgo-dev-kit/internal/validate"
+	"github.com/verse91/fsgo-dev-kit/pkg/utils"
+)
+
+// minSideBySideWidth is the narrowest terminal that shows the preview next to
+// the questions instead of below them
+const minSideBySideWidth = 100
+
+// Review step actions
+const (
+	actionGenerate   = "Generate project"
+	actionSaveSpec   = "Save as spec file"
+	actionSavePreset = "Save as preset"
+	actionAbort      = "Abort"
+)
+
+// question describes how a configuration field is asked
+type question struct {
+	label   string
+	message string
+	help    string
+}
+
+// questions holds the wording of each step
+var questions = map[types.ConfigField]question{
+	types.FieldPath:           {"Project", "Enter your project name or path (relative to current directory)", "Use '.' for current directory or specify a new directory name"},
+	types.FieldType:           {"Type", "Select project type", "Web: Full-stack with frontend + backend, API: Backend only"},
+	types.FieldBackend:        {"Backend", "Choose backend framework", "Select the Go web framework for your backend"},
+	types.FieldModulePath:     {"Module path", "Enter the Go module path of the backend", "Used for go mod init and the imports of the server, e.g. github.com/you/my-app/server"},
+	types.FieldFrontend:       {"Frontend", "Choose frontend framework", "Select the frontend framework/library"},
+	types.FieldTypeScript:     {"TypeScript", "Use TypeScript?", ""},
+	types.FieldTailwindCSS:    {"Tailwind CSS", "Use Tailwind CSS?", ""},
+	types.FieldESLint:         {"ESLint", "Use ESLint?", ""},
+	types.FieldPackageManager: {"Package manager", "Choose package manager", "Used to create the frontend and install its dependencies"},
+}
+
+// option is a single choice of a select step
+type option struct {
+	value       string
+	description string
+}
+
+// model is the Bubble Tea model of the wizard. Answers are written to config
+// as soon as they are highlighted so the preview always matches the screen.
+type model struct {
+	wizard *Wizard
+	config *types.ProjectConfig
+	fields []types.ConfigField
+
+	// current is the field being asked; empty on the review step
+	current   types.ConfigField
+	reviewing bool
+	cursor    int
+	input     textinput.Model
+
+	// moduleDefault is the module path suggested for the project path; an
+	// answer equal to it is suggested again when the path changes
+	moduleDefault string
+
+	// confirming is set while asking whether to use a non-empty directory
+	confirming bool
+	// saving is the review action whose file or preset name is being typed
+	saving  string
+	message string
+	isError bool
+
+	aborted bool
+	width   int
+}
+
+// newModel creates a wizard model asking for fields. Missing answers start
+// from the wizard defaults. With review set the model opens on the review step.
+func newModel(w *Wizard, config *types.ProjectConfig, fields []types.ConfigField, review bool) *model {
+	// Only the asked fields take their value from the defaults
+	set := types.FieldSet{}
+	for _, field := range types.GetConfigFields() {
+		set[field] = !slices.Contains(fields, field)
+	}
+	defaults := *w.defaults
+	if defaults.Path == "" {
+		defaults.Path = "."
+	}
+	defaults.Name = projectName(defaults.Path)
+	if !review {
+		set.FillMissing(config, &defaults)
+	}
+
+	input := textinput.New()
+	input.Prompt = "› "
+
+	m := &model{wizard: w, config: config, fields: fields, input: input}
+	m.restrictFrontend()
+	if config.ModulePath != "" {
+		m.moduleDefault = utils.DefaultModulePath(config.Path, config.Name)
+	}
+
+	steps := m.steps()
+	if review || len(steps) == 0 {
+		m.enterReview()
+	} else {
+		m.enter(steps[0])
+	}
+	return m
+}
+
+// Init implements tea.Model
+func (m *model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update implements tea.Model
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			m.aborted = true
+			return m, tea.Quit
+		}
+		switch {
+		case m.confirming:
+			return m.updateConfirm(msg)
+		case m.saving != "":
+			return m.updateSave(msg)
+		case m.reviewing:
+			return m.updateReview(msg)
+		case m.current == types.FieldPath:
+			return m.updatePath(msg)
+		case m.current == types.FieldModulePath:
+			return m.updateModulePath(msg)
+		default:
+			return m.updateSelect(msg)
+		}
+	}
+	return m, nil
+}
+
+// updatePath handles keys on the project path step
+func (m *model) updatePath(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "tab":
+		path := m.input.Value()
+		if err := validateProjectPath(path); err != nil {
+			m.setMessage(err.Error(), true)
+			return m, nil
+		}
+		if !m.wizard.allowNonEmpty {
+			var notEmpty *validate.DirNotEmptyError
+			if err := validate.TargetDir(path); errors.As(err, &notEmpty) {
+				m.confirming = true
+				m.setMessage(fmt.Sprintf("%s is not empty. Generate into it anyway? (y/N)", path), false)
+				return m, nil
+			}
+		}
+		return m, m.next()
+	case "shift+tab", "esc":
+		return m, m.prev()
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.config.Path = m.input.Value()
+	m.config.Name = projectName(m.config.Path)
+	m.message = ""
+	return m, cmd
+}
+
+// updateModulePath handles keys on the module path step. The answer is
+// checked before leaving the step in either direction.
+func (m *model) updateModulePath(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "tab", "shift+tab", "esc":
+		if err := validate.ModulePath(m.input.Value()); err != nil {
+			m.setMessage(err.Error(), true)
+			return m, nil
+		}
+		if msg.String() == "enter" || msg.String() == "tab" {
+			return m, m.next()
+		}
+		return m, m.prev()
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.config.ModulePath = m.input.Value()
+	m.message = ""
+	return m, cmd
+}
+
+// updateConfirm handles the answer to the non-empty directory question
+func (m *model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		m.confirming = false
+		m.message = ""
+		return m, m.next()
+	case "n", "N", "enter", "esc":
+		m.confirming = false
+		m.message = ""
+	}
+	return m, nil
+}
+
+// updateSelect handles keys on a step with a list of options
+func (m *model) updateSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	options := m.options(m.current)
+	switch msg.String() {
+	case "up", "k":
+		m.move(-1, options)
+	case "down", "j":
+		m.move(1, options)
+	case "enter", "tab", "right", "l":
+		return m, m.next()
+	case "shift+tab", "esc", "left", "h":
+		return m, m.prev()
+	}
+	return m, nil
+}
+
+// updateReview handles keys on the review step
+func (m *model) updateReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	actions := m.actions()
+	switch msg.String() {
+	case "up", "k":
+		m.cursor = (m.cursor + len(actions) - 1) % len(actions)
+	case "down", "j":
+		m.cursor = (m.cursor + 1) % len(actions)
+	case "shift+tab", "esc", "left", "h":
+		return m, m.prev()
+	case "enter":
+		switch actions[m.cursor].value {
+		case actionGenerate:
+			return m, tea.Quit
+		case actionAbort:
+			m.aborted = true
+			return m, tea.Quit
+		case actionSaveSpec:
+			return m, m.startSave(actionSaveSpec, "fsgo.yaml")
+		case actionSavePreset:
+			return m, m.startSave(actionSavePreset, "default")
+		}
+	}
+	return m, nil
+}
+
+// updateSave handles typing the file or preset name of a save action
+func (m *model) updateSave(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.saving = ""
+		m.message = ""
+		return m, nil
+	case "enter":
+		name := strings.TrimSpace(m.input.Value())
+		if name == "" {
+			m.setMessage("a value is required", true)
+			return m, nil
+		}
+		m.finish()
+		if m.saving == actionSaveSpec {
+			if err := spec.Save(name, m.config); err != nil {
+				m.setMessage(fmt.Sprintf("error saving spec file: %v", err), true)
+				return m, nil
+			}
+			m.setMessage("Saved spec "+name, false)
+		} else {
+			if err := userconfig.SavePreset(name, m.config); err != nil {
+				m.setMessage(fmt.Sprintf("error saving preset: %v", err), true)
+				return m, nil
+			}
+			m.setMessage("Saved preset "+name, false)
+		}
+		m.saving = ""
+		m.input.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// startSave asks for the name a review action saves under
+func (m *model) startSave(action, defaultValue string) tea.Cmd {
+	m.saving = action
+	m.message = ""
+	m.input.SetValue(defaultValue)
+	m.input.CursorEnd()
+	return m.input.Focus()
+}
+
+// steps returns the fields to ask for the current answers: frontend fields
+// only for Web projects and only the options the chosen frontend supports
+func (m *model) steps() []types.ConfigField {
+	var steps []types.ConfigField
+	for _, field := range m.fields {
+		if types.IsFrontendField(field) {
+			if m.config.Type != types.WebProject || m.config.Frontend == nil {
+				continue
+			}
+			if field != types.FieldFrontend && !m.capabilities().Supports(field) {
+				continue
+			}
+		}
+		steps = append(steps, field)
+	}
+	return steps
+}
+
+// enter makes field the current step
+func (m *model) enter(field types.ConfigField) tea.Cmd {
+	m.current = field
+	m.reviewing = false
+	m.message = ""
+
+	switch field {
+	case types.FieldPath:
+		m.input.SetValue(m.config.Path)
+		m.input.CursorEnd()
+		return m.input.Focus()
+	case types.FieldModulePath:
+		suggested := utils.DefaultModulePath(m.config.Path, m.config.Name)
+		if m.config.ModulePath == "" || m.config.ModulePath == m.moduleDefault {
+			m.config.ModulePath = suggested
+		}
+		m.moduleDefault = suggested
+		m.input.SetValue(m.config.ModulePath)
+		m.input.CursorEnd()
+		return m.input.Focus()
+	}
+	m.input.Blur()
+
+	options := m.options(field)
+	m.cursor = 0
+	for i, opt := range options {
+		if opt.value == m.value(field) {
+			m.cursor = i
+		}
+	}
+	if len(options) > 0 {
+		m.setValue(field, options[m.cursor].value)
+	}
+	return nil
+}
+
+// enterReview shows the review step, or finishes when there is none
+func (m *model) enterReview() tea.Cmd {
+	if !m.wizard.review {
+		return tea.Quit
+	}
+	m.current = ""
+	m.reviewing = true
+	m.cursor = 0
+	m.message = ""
+	m.input.Blur()
+	return nil
+}
+
+// next moves to the step after the current one
+func (m *model) next() tea.Cmd {
+	steps := m.steps()
+	i := slices.Index(steps, m.current)
+	if i < 0 || i == len(steps)-1 {
+		return m.enterReview()
+	}
+	return m.enter(steps[i+1])
+}
+
+// prev moves to the step before the current one
+func (m *model) prev() tea.Cmd {
+	steps := m.steps()
+	if m.reviewing {
+		if len(steps) == 0 {
+			return nil
+		}
+		return m.enter(steps[len(steps)-1])
+	}
+	if i := slices.Index(steps, m.current); i > 0 {
+		return m.enter(steps[i-1])
+	}
+	return nil
+}
+
+// move highlights the option delta positions away and applies it
+func (m *model) move(delta int, options []option) {
+	if len(options) == 0 {
+		return
+	}
+	m.cursor = (m.cursor + delta + len(options)) % len(options)
+	m.setValue(m.current, options[m.cursor].value)
+}
+
+// options returns the choices of a select step
+func (m *model) options(field types.ConfigField) []option {
+	switch field {
+	case types.FieldType:
+		return []option{
+			{string(types.WebProject), "Full-stack with frontend + backend"},
+			{string(types.APIProject), "Backend only"},
+		}
+	case types.FieldBackend:
+		return descriptorOptions(m.wizard.registry.DescribeBackends())
+	case types.FieldFrontend:
+		return descriptorOptions(m.wizard.registry.DescribeFrontends())
+	case types.FieldTypeScript, types.FieldTailwindCSS, types.FieldESLint:
+		return []option{{"Yes", ""}, {"No", ""}}
+	case types.FieldPackageManager:
+		var options []option
+		for _, pm := range m.capabilities().PackageManagers {
+			options = append(options, option{string(pm), ""})
+		}
+		return options
+	}
+	return nil
+}
+
+// actions returns the choices of the review step
+func (m *model) actions() []option {
+	return []option{
+		{actionGenerate, "Write the project to disk"},
+		{actionSaveSpec, "Replay later with 'fsgo new --file'"},
+		{actionSavePreset, "Reuse later with 'fsgo new --preset'"},
+		{actionAbort, "Quit without writing project files"},
+	}
+}
+
+// descriptorOptions turns generator descriptors into options
+func descriptorOptions(descriptors []types.GeneratorDescriptor) []option {
+	options := make([]option, len(descriptors))
+	for i, d := range descriptors {
+		options[i] = option{d.DisplayName, d.Description}
+	}
+	return options
+}
+
+// value returns the current answer of a select step
+func (m *model) value(field types.ConfigField) string {
+	frontend := m.config.Frontend
+	if frontend == nil {
+		frontend = &types.FrontendConfig{}
+	}
+	switch field {
+	case types.FieldType:
+		return string(m.config.Type)
+	case types.FieldBackend:
+		return string(m.config.BackendFramework)
+	case types.FieldModulePath:
+		return m.config.ModulePath
+	case types.FieldFrontend:
+		return string(frontend.Framework)
+	case types.FieldTypeScript:
+		return yesNo(frontend.TypeScript)
+	case types.FieldTailwindCSS:
+		return yesNo(frontend.TailwindCSS)
+	case types.FieldESLint:
+		return yesNo(frontend.ESLint)
+	case types.FieldPackageManager:
+		return string(frontend.PackageManager)
+	}
+	return ""
+}
+
+// setValue applies the answer of a select step to the configuration
+func (m *model) setValue(field types.ConfigField, value string) {
+	switch field {
+	case types.FieldType:
+		m.config.Type = types.ProjectType(value)
+		if m.config.Type == types.WebProject && m.config.Frontend == nil {
+			frontend := *m.wizard.defaults.Frontend
+			m.config.Frontend = &frontend
+		}
+	case types.FieldBackend:
+		m.config.BackendFramework = types.BackendFramework(value)
+	case types.FieldFrontend:
+		m.config.Frontend.Framework = types.FrontendFramework(value)
+	case types.FieldTypeScript:
+		m.config.Frontend.TypeScript = value == "Yes"
+	case types.FieldTailwindCSS:
+		m.config.Frontend.TailwindCSS = value == "Yes"
+	case types.FieldESLint:
+		m.config.Frontend.ESLint = value == "Yes"
+	case types.FieldPackageManager:
+		m.config.Frontend.PackageManager = types.PackageManager(value)
+	}
+	m.restrictFrontend()
+}
+
+// restrictFrontend turns off options the chosen frontend does not support
+func (m *model) restrictFrontend() {
+	if m.config.Frontend != nil && m.config.Frontend.Framework != "" {
+		_ = m.capabilities().Restrict(m.config.Frontend, types.FieldSet{})
+	}
+}
+
+// capabilities returns the options supported by the chosen frontend
+func (m *model) capabilities() types.FrontendCapabilities {
+	if m.config.Frontend == nil {
+		return types.FrontendCapabilities{}
+	}
+	capabilities, _ := m.wizard.registry.GetFrontendCapabilities(m.config.Frontend.Framework)
+	return capabilities
+}
+
+// finish drops answers that don't apply to the final configuration
+func (m *model) finish() {
+	if m.config.Type != types.WebProject {
+		m.config.Frontend = nil
+	}
+}
+
+// setMessage shows a status or error line below the current step
+func (m *model) setMessage(message string, isError bool) {
+	m.message = message
+	m.isError = isError
+}
+
+// View implements tea.Model
+func (m *model) View() string {
+	if m.width == 0 {
+		return ""
+	}
+
+	left := lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("Creating a new project"),
+		"",
+		m.viewSteps(),
+		"",
+		m.viewQuestion(),
+	)
+	right := m.viewPreview()
+
+	if m.width < minSideBySideWidth {
+		width := m.width - 2
+		return lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.NewStyle().Width(width).Render(left),
+			"",
+			paneStyle.Width(width-2).Render(right),
+			m.viewKeys(),
+		)
+	}
+
+	half := m.width / 2
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(half-1).PaddingRight(2).Render(left),
+			paneStyle.Width(m.width-half-3).Render(right),
+		),
+		m.viewKeys(),
+	)
+}
+
+// viewSteps lists every step with its answer, marking the current one
+func (m *model) viewSteps() string {
+	var lines []string
+	for _, field := range m.steps() {
+		value := m.value(field)
+		if field == types.FieldPath {
+			value = m.config.Path
+		}
+		line := fmt.Sprintf("%s: %s", questions[field].label, value)
+		if field == m.current {
+			lines = append(lines, selectedStyle.Render("› "+line))
+		} else {
+			lines = append(lines, mutedStyle.Render("  "+line))
+		}
+	}
+	if m.wizard.review {
+		if m.reviewing {
+			lines = append(lines, selectedStyle.Render("› Review"))
+		} else {
+			lines = append(lines, mutedStyle.Render("  Review"))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// viewQuestion renders the current step
+func (m *model) viewQuestion() string {
+	var b strings.Builder
+
+	switch {
+	case m.saving != "":
+		b.WriteString(questionStyle.Render(m.saving) + "\n")
+		b.WriteString(m.input.View() + "\n")
+	case m.reviewing:
+		b.WriteString(questionStyle.Render("What would you like to do?") + "\n")
+		b.WriteString(m.viewOptions(m.actions()))
+	case m.current == types.FieldPath || m.current == types.FieldModulePath:
+		b.WriteString(questionStyle.Render(questions[m.current].message) + "\n")
+		b.WriteString(mutedStyle.Render(questions[m.current].help) + "\n")
+		b.WriteString(m.input.View() + "\n")
+	default:
+		b.WriteString(questionStyle.Render(questions[m.current].message) + "\n")
+		if help := questions[m.current].help; help != "" {
+			b.WriteString(mutedStyle.Render(help) + "\n")
+		}
+		b.WriteString(m.viewOptions(m.options(m.current)))
+	}
+
+	if m.message != "" {
+		style := selectedStyle
+		if m.isError {
+			style = errorStyle
+		}
+		b.WriteString("\n" + style.Render(m.message) + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// viewOptions renders a list of options with the cursor
+func (m *model) viewOptions(options []option) string {
+	var b strings.Builder
+	for i, opt := range options {
+		line := "  " + opt.value
+		if i == m.cursor {
+			line = selectedStyle.Render("❯ " + opt.value)
+		}
+		if opt.description != "" {
+			line += mutedStyle.Render(" - " + opt.description)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// viewPreview renders the files and commands the current answers produce
+func (m *model) viewPreview() string {
+	ctx := templates.NewContext(context.Background(), m.wizard.templates)
+	preview := m.wizard.registry.Preview(ctx, m.config)
+	root := m.config.Path
+	if root == "" || root == "." {
+		root = m.config.Name
+	}
+
+	var commands []string
+	for _, command := range preview.Commands {
+		commands = append(commands, mutedStyle.Render("$ ")+command)
+	}
+	if len(commands) == 0 {
+		commands = append(commands, mutedStyle.Render("none"))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("Files"),
+		tree.Render(root, preview.Files, nil),
+		"",
+		titleStyle.Render("Commands"),
+		strings.Join(commands, "\n"),
+	)
+}
+
+// viewKeys renders the key help for the current step
+func (m *model) viewKeys() string {
+	keys := "↑/↓ select • enter next • esc back • ctrl+c quit"
+	switch {
+	case m.confirming:
+		keys = "y yes • n no"
+	case m.saving != "":
+		keys = "enter save • esc cancel"
+	case m.reviewing:
+		keys = "↑/↓ select • enter confirm • esc back • ctrl+c quit"
+	case m.current == types.FieldPath || m.current == types.FieldModulePath:
+		keys = "enter next • esc back • ctrl+c quit"
+	}
+	return "\n" + mutedStyle.Render(keys)
+}
+
+// validateProjectPath checks a project path and the name derived from it
+func validateProjectPath(path string) error {
+	if err := validate.ProjectPath(path); err != nil {
+		return err
+	}
+	return validate.ProjectName(projectName(path))
+}
@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/verse91/fsgo-dev-kit/internal/prompt"
+	"github.com/verse91/fsgo-dev-kit/internal/staging"
+	"github.com/verse91/fsgo-dev-kit/internal/templates"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/pkg/utils"
+)
+
+// ErrAborted is returned when the user quits the wizard before finishing
+var ErrAborted = errors.New("aborted by user")
+
+// Registry offers the frameworks the wizard lets the user choose from and
+// previews what a configuration generates
+type Registry interface {
+	types.FrameworkRegistry
+	DescribeBackends() []types.GeneratorDescriptor
+	DescribeFrontends() []types.GeneratorDescriptor
+	Preview(ctx context.Context, config *types.ProjectConfig) types.Preview
+}
+
+// Wizard is a full-screen project wizard. Every question is a step that can
+// be revisited with the keyboard, and a preview of the files and commands the
+// current answers produce is shown next to it.
+type Wizard struct {
+	registry      Registry
+	templates     *templates.Library
+	defaults      *types.ProjectConfig
+	out           io.Writer
+	review        bool
+	allowNonEmpty bool
+	// reviewed is set once the answers have been confirmed on the review step
+	reviewed bool
+}
+
+// NewWizard creates a wizard offering the frameworks of registry. The wizard
+// ends with a review step unless SetReview(false) is called.
+func NewWizard(registry Registry) *Wizard {
+	return &Wizard{
+		registry:  registry,
+		templates: templates.Builtin(),
+		defaults:  types.DefaultProjectConfig(),
+		out:       os.Stdout,
+		review:    true,
+	}
+}
+
+// SetTemplates changes the library the preview looks up template sets in,
+// e.g. to one with override directories or a template pack
+func (w *Wizard) SetTemplates(library *templates.Library) {
+	w.templates = library
+}
+
+// SetDefaults changes the answers the wizard starts from
+func (w *Wizard) SetDefaults(defaults *types.ProjectConfig) {
+	if defaults.Frontend == nil {
+		defaults.Frontend = types.DefaultProjectConfig().Frontend
+	}
+	w.defaults = defaults
+}
+
+// SetAllowNonEmpty skips the confirmation asked before generating into a
+// directory that already contains files
+func (w *Wizard) SetAllowNonEmpty(allow bool) {
+	w.allowNonEmpty = allow
+}
+
+// SetReview controls whether the wizard ends with the review step that can
+// save the answers or abort
+func (w *Wizard) SetReview(review bool) {
+	w.review = review
+}
+
+// GetProjectConfig runs the wizard for every configuration field
+func (w *Wizard) GetProjectConfig() (*types.ProjectConfig, error) {
+	config := &types.ProjectConfig{}
+	if err := w.CompleteProjectConfig(config, types.GetConfigFields()); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// CompleteProjectConfig runs the wizard for the given fields of a partially
+// filled configuration. It returns ErrAborted when the user quits.
+func (w *Wizard) CompleteProjectConfig(config *types.ProjectConfig, fields []types.ConfigField) error {
+	if err := w.run(newModel(w, config, fields, false)); err != nil {
+		return err
+	}
+	w.reviewed = w.review
+
+	w.ShowSummary(config)
+	return nil
+}
+
+// Review lets the user confirm config, edit any answer, save it or abort. It
+// returns false when the user aborts. Answers already confirmed by
+// CompleteProjectConfig are not reviewed a second time.
+func (w *Wizard) Review(config *types.ProjectConfig) (bool, error) {
+	if w.reviewed {
+		w.reviewed = false
+		return true, nil
+	}
+
+	err := w.run(newModel(w, config, types.GetConfigFields(), true))
+	if errors.Is(err, ErrAborted) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	w.ShowSummary(config)
+	return true, nil
+}
+
+// ShowSummary prints the configuration in a box
+func (w *Wizard) ShowSummary(config *types.ProjectConfig) {
+	fmt.Fprintln(w.out, paneStyle.Render(titleStyle.Render("Project Summary")+"\n\n"+summary(config)))
+	fmt.Fprintln(w.out)
+}
+
+// ResolveConflict asks what to do with a generated file that already exists.
+// Conflicts come up after the full-screen wizard has closed, so they are
+// asked inline with the classic prompts.
+func (w *Wizard) ResolveConflict(conflict staging.Conflict) (staging.Resolution, error) {
+	return prompt.NewProjectPrompt(w.registry).ResolveConflict(conflict)
+}
+
+// run shows m full-screen until the user finishes or quits
+func (w *Wizard) run(m *model) error {
+	result, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	if err != nil {
+		return fmt.Errorf("error running wizard: %v", err)
+	}
+	if result.(*model).aborted {
+		return ErrAborted
+	}
+	m.finish()
+	return nil
+}
+
+// summary lists the answers of config, one per line
+func summary(config *types.ProjectConfig) string {
+	rows := [][2]string{
+		{"Name", config.Name},
+		{"Path", config.Path},
+		{"Type", string(config.Type)},
+		{"Backend", string(config.BackendFramework)},
+		{"Module path", config.ModulePath},
+	}
+	if config.Type == types.WebProject && config.Frontend != nil {
+		rows = append(rows,
+			[2]string{"Frontend", string(config.Frontend.Framework)},
+			[2]string{"TypeScript", yesNo(config.Frontend.TypeScript)},
+			[2]string{"Tailwind CSS", yesNo(config.Frontend.TailwindCSS)},
+			[2]string{"ESLint", yesNo(config.Frontend.ESLint)},
+		)
+		if config.Frontend.PackageManager != "" {
+			rows = append(rows, [2]string{"Package manager", string(config.Frontend.PackageManager)})
+		}
+	}
+
+	var lines []string
+	for _, row := range rows {
+		// Presets have no name, path or module path
+		if row[1] == "" {
+			continue
+		}
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("%-16s", row[0]))+row[1])
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// projectName derives the project name from path, falling back to path itself
+func projectName(path string) string {
+	name, err := utils.ProjectNameFromPath(path)
+	if err != nil {
+		return path
+	}
+	return name
+}
+
+// yesNo formats a boolean answer
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
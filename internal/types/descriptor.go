@@ -0,0 +1,47 @@
+package types
+
+// GeneratorDescriptor describes what a generator creates. It is rendered by
+// 'fsgo list', the root help text and shell completions, so the generators
+// themselves are the single source of truth for what fsgo supports.
+type GeneratorDescriptor struct {
+	// Name is the value accepted by --backend or --frontend
+	Name string `json:"name"`
+	// DisplayName is the framework name shown in prompts and summaries
+	DisplayName string `json:"display-name"`
+	// Description is a one-line summary of the framework
+	Description string `json:"description"`
+	// Homepage links to the framework's documentation
+	Homepage string `json:"homepage"`
+	// RequiredTools are the executables that must be on PATH to generate
+	RequiredTools []string `json:"required-tools"`
+	// Options are the configuration fields the generator supports
+	Options []ConfigField `json:"options"`
+	// PackageManagers lists the supported package managers, preferred first
+	PackageManagers []PackageManager `json:"package-managers,omitempty"`
+	// Dependencies are the packages installed into the generated project
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Layout lists the main directories and files produced, relative to the
+	// project root; directories end in a slash
+	Layout []string `json:"layout"`
+}
+
+// Preview lists what generating a configuration produces
+type Preview struct {
+	// Files are the main directories and files, relative to the project root;
+	// directories end in a slash
+	Files []string
+	// Commands are the external commands run while generating
+	Commands []string
+}
+
+// Options returns the frontend configuration fields the capabilities allow
+// the user to choose, in wizard order
+func (c FrontendCapabilities) Options() []ConfigField {
+	options := []ConfigField{}
+	for _, field := range GetConfigFields() {
+		if field != FieldFrontend && IsFrontendField(field) && c.Supports(field) {
+			options = append(options, field)
+		}
+	}
+	return options
+}
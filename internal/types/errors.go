@@ -0,0 +1,77 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors that classify why generating a project failed. Errors returned
+// while configuring or generating a project wrap the one that applies, so
+// callers can tell them apart with errors.Is. A file that already exists is
+// reported with staging.ErrConflict and cancellation with context.Canceled.
+var (
+	// ErrInvalidConfig is wrapped by errors about option values that can't
+	// be used together or at all
+	ErrInvalidConfig = errors.New("invalid configuration")
+	// ErrUnknownFramework is wrapped by errors about frameworks no generator
+	// is registered for
+	ErrUnknownFramework = errors.New("unknown framework")
+	// ErrMissingTool is wrapped by errors about executables generation needs
+	// that are not on PATH
+	ErrMissingTool = errors.New("required tool not found")
+	// ErrDependencyInstall is wrapped by errors about Go module setup, such as
+	// go get, that failed with --strict
+	ErrDependencyInstall = errors.New("dependency installation failed")
+)
+
+// ConfigError is an option value that can't be used
+type ConfigError struct {
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	return e.Message
+}
+
+// Unwrap classifies the error as ErrInvalidConfig
+func (e *ConfigError) Unwrap() error {
+	return ErrInvalidConfig
+}
+
+// configErrorf returns a ConfigError with a formatted message
+func configErrorf(format string, args ...any) error {
+	return &ConfigError{Message: fmt.Sprintf(format, args...)}
+}
+
+// UnknownFrameworkError is a framework name that matches no known framework
+// or no registered generator
+type UnknownFrameworkError struct {
+	// Kind is backend or frontend
+	Kind string
+	Name string
+}
+
+func (e *UnknownFrameworkError) Error() string {
+	return fmt.Sprintf("unknown %s framework %q", e.Kind, e.Name)
+}
+
+// Unwrap classifies the error as ErrUnknownFramework
+func (e *UnknownFrameworkError) Unwrap() error {
+	return ErrUnknownFramework
+}
+
+// MissingToolError lists the executables generation needs that are not on
+// PATH
+type MissingToolError struct {
+	Tools []string
+}
+
+func (e *MissingToolError) Error() string {
+	return fmt.Sprintf("required tools not found on PATH: %s (install them or choose other frameworks)", strings.Join(e.Tools, ", "))
+}
+
+// Unwrap classifies the error as ErrMissingTool
+func (e *MissingToolError) Unwrap() error {
+	return ErrMissingTool
+}
@@ -0,0 +1,168 @@
+package types
+
+import "slices"
+
+// ConfigField identifies a single option of a ProjectConfig that can be
+// supplied by a flag, a spec file or an interactive prompt
+type ConfigField string
+
+const (
+	FieldPath    ConfigField = "path"
+	FieldType    ConfigField = "type"
+	FieldBackend ConfigField = "backend"
+	// FieldModulePath is optional: it defaults to a module path derived from
+	// the git remote or the project name
+	FieldModulePath  ConfigField = "module-path"
+	FieldFrontend    ConfigField = "frontend"
+	FieldTypeScript  ConfigField = "typescript"
+	FieldTailwindCSS ConfigField = "tailwind"
+	FieldESLint      ConfigField = "eslint"
+	// FieldPackageManager is optional: it falls back to the preferred package
+	// manager of the frontend generator
+	FieldPackageManager ConfigField = "package-manager"
+)
+
+// FieldSet records which fields of a ProjectConfig have been supplied
+type FieldSet map[ConfigField]bool
+
+// GetConfigFields returns all configuration fields in the order they are asked
+func GetConfigFields() []ConfigField {
+	return []ConfigField{
+		FieldPath,
+		FieldType,
+		FieldBackend,
+		FieldModulePath,
+		FieldFrontend,
+		FieldTypeScript,
+		FieldTailwindCSS,
+		FieldESLint,
+		FieldPackageManager,
+	}
+}
+
+// IsFrontendField reports whether field only applies to web projects
+func IsFrontendField(field ConfigField) bool {
+	switch field {
+	case FieldFrontend, FieldTypeScript, FieldTailwindCSS, FieldESLint, FieldPackageManager:
+		return true
+	}
+	return false
+}
+
+// Missing returns the fields not in the set that are still needed for config.
+// Frontend fields are only reported when the project type is unknown or Web,
+// and the optional module path and package manager are never reported.
+func (s FieldSet) Missing(config *ProjectConfig) []ConfigField {
+	var missing []ConfigField
+	for _, field := range GetConfigFields() {
+		if s[field] || field == FieldModulePath || field == FieldPackageManager {
+			continue
+		}
+		if IsFrontendField(field) && s[FieldType] && config.Type != WebProject {
+			continue
+		}
+		missing = append(missing, field)
+	}
+	return missing
+}
+
+// Prompted returns the fields an interactive run asks for: the missing ones
+// and, when it was not supplied, the module path, which is offered with its
+// default filled in. Runs that don't prompt use Missing, so the module path
+// keeps its default without being required.
+func (s FieldSet) Prompted(config *ProjectConfig) []ConfigField {
+	missing := s.Missing(config)
+	if s[FieldModulePath] {
+		return missing
+	}
+
+	fields := make([]ConfigField, 0, len(missing)+1)
+	for _, field := range GetConfigFields() {
+		if field == FieldModulePath || slices.Contains(missing, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// FillMissing copies every field that is not in the set from defaults into config
+func (s FieldSet) FillMissing(config, defaults *ProjectConfig) {
+	if !s[FieldPath] {
+		config.Name = defaults.Name
+		config.Path = defaults.Path
+	}
+	if !s[FieldType] {
+		config.Type = defaults.Type
+	}
+	if !s[FieldBackend] {
+		config.BackendFramework = defaults.BackendFramework
+	}
+	if !s[FieldModulePath] {
+		config.ModulePath = defaults.ModulePath
+	}
+
+	if config.Type != WebProject {
+		config.Frontend = nil
+		return
+	}
+	if config.Frontend == nil {
+		config.Frontend = &FrontendConfig{}
+	}
+	fallback := defaults.Frontend
+	if fallback == nil {
+		fallback = &FrontendConfig{}
+	}
+	if !s[FieldFrontend] {
+		config.Frontend.Framework = fallback.Framework
+	}
+	if !s[FieldTypeScript] {
+		config.Frontend.TypeScript = fallback.TypeScript
+	}
+	if !s[FieldTailwindCSS] {
+		config.Frontend.TailwindCSS = fallback.TailwindCSS
+	}
+	if !s[FieldESLint] {
+		config.Frontend.ESLint = fallback.ESLint
+	}
+	if !s[FieldPackageManager] {
+		config.Frontend.PackageManager = fallback.PackageManager
+	}
+}
+
+// Merge copies the fields listed in set from src into config and records
+// them as supplied
+func (s FieldSet) Merge(config *ProjectConfig, src *ProjectConfig, set FieldSet) {
+	for field := range set {
+		if IsFrontendField(field) {
+			if src.Frontend == nil {
+				continue
+			}
+			if config.Frontend == nil {
+				config.Frontend = &FrontendConfig{}
+			}
+		}
+		s[field] = true
+
+		switch field {
+		case FieldPath:
+			config.Name = src.Name
+			config.Path = src.Path
+		case FieldType:
+			config.Type = src.Type
+		case FieldBackend:
+			config.BackendFramework = src.BackendFramework
+		case FieldModulePath:
+			config.ModulePath = src.ModulePath
+		case FieldFrontend:
+			config.Frontend.Framework = src.Frontend.Framework
+		case FieldTypeScript:
+			config.Frontend.TypeScript = src.Frontend.TypeScript
+		case FieldTailwindCSS:
+			config.Frontend.TailwindCSS = src.Frontend.TailwindCSS
+		case FieldESLint:
+			config.Frontend.ESLint = src.Frontend.ESLint
+		case FieldPackageManager:
+			config.Frontend.PackageManager = src.Frontend.PackageManager
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package types_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+func TestMissingAndPrompted(t *testing.T) {
+	api := &types.ProjectConfig{Type: types.APIProject}
+	tests := []struct {
+		name     string
+		set      types.FieldSet
+		config   *types.ProjectConfig
+		missing  []types.ConfigField
+		prompted []types.ConfigField
+	}{
+		{
+			"nothing supplied",
+			types.FieldSet{},
+			&types.ProjectConfig{},
+			[]types.ConfigField{types.FieldPath, types.FieldType, types.FieldBackend, types.FieldFrontend, types.FieldTypeScript, types.FieldTailwindCSS, types.FieldESLint},
+			[]types.ConfigField{types.FieldPath, types.FieldType, types.FieldBackend, types.FieldModulePath, types.FieldFrontend, types.FieldTypeScript, types.FieldTailwindCSS, types.FieldESLint},
+		},
+		{
+			"api project",
+			types.FieldSet{types.FieldType: true},
+			api,
+			[]types.ConfigField{types.FieldPath, types.FieldBackend},
+			[]types.ConfigField{types.FieldPath, types.FieldBackend, types.FieldModulePath},
+		},
+		{
+			"module path supplied",
+			types.FieldSet{types.FieldType: true, types.FieldModulePath: true},
+			api,
+			[]types.ConfigField{types.FieldPath, types.FieldBackend},
+			[]types.ConfigField{types.FieldPath, types.FieldBackend},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.set.Missing(tt.config); !slices.Equal(got, tt.missing) {
+				t.Errorf("Missing = %q, want %q", got, tt.missing)
+			}
+			if got := tt.set.Prompted(tt.config); !slices.Equal(got, tt.prompted) {
+				t.Errorf("Prompted = %q, want %q", got, tt.prompted)
+			}
+		})
+	}
+}
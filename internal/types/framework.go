@@ -1,5 +1,10 @@
 package types
 
+import (
+	"slices"
+	"strings"
+)
+
 // ProjectType represents the type of project to create
 type ProjectType string
 
@@ -22,12 +27,22 @@ const (
 type FrontendFramework string
 
 const (
-	NextJS     FrontendFramework = "Next.js"
-	React      FrontendFramework = "React"
-	Vue        FrontendFramework = "Vue"
-	Svelte     FrontendFramework = "Svelte"
-	SvelteKit  FrontendFramework = "SvelteKit"
-	Solid      FrontendFramework = "Solid"
+	NextJS    FrontendFramework = "Next.js"
+	React     FrontendFramework = "React"
+	Vue       FrontendFramework = "Vue"
+	Svelte    FrontendFramework = "Svelte"
+	SvelteKit FrontendFramework = "SvelteKit"
+	Solid     FrontendFramework = "Solid"
+)
+
+// PackageManager represents a JavaScript package manager
+type PackageManager string
+
+const (
+	Bun  PackageManager = "bun"
+	Npm  PackageManager = "npm"
+	Pnpm PackageManager = "pnpm"
+	Yarn PackageManager = "yarn"
 )
 
 // ProjectConfig holds the configuration for generating a project
@@ -36,15 +51,40 @@ type ProjectConfig struct {
 	Path             string
 	Type             ProjectType
 	BackendFramework BackendFramework
-	Frontend         *FrontendConfig // nil for API-only projects
+	// ModulePath is the Go module path of the server; empty means the
+	// default derived from the git remote or the project name
+	ModulePath string
+	Frontend   *FrontendConfig // nil for API-only projects
 }
 
 // FrontendConfig holds frontend-specific configuration
 type FrontendConfig struct {
-	Framework FrontendFramework
-	TypeScript bool
+	Framework      FrontendFramework
+	TypeScript     bool
+	TailwindCSS    bool
+	ESLint         bool
+	PackageManager PackageManager
+}
+
+// FrontendCapabilities describes the options a frontend generator supports
+type FrontendCapabilities struct {
+	TypeScript  bool
 	TailwindCSS bool
-	ESLint     bool
+	ESLint      bool
+	// PackageManagers lists the supported package managers, preferred first
+	PackageManagers []PackageManager
+}
+
+// FrameworkRegistry reports which frameworks can be generated, in the order
+// they should be offered, and what their generators support. Its Parse
+// methods also know frameworks that are only registered for a run, such as
+// those of a template pack.
+type FrameworkRegistry interface {
+	GetAvailableBackendFrameworks() []BackendFramework
+	GetAvailableFrontendFrameworks() []FrontendFramework
+	ParseBackendFramework(s string) (BackendFramework, error)
+	ParseFrontendFramework(s string) (FrontendFramework, error)
+	GetFrontendCapabilities(framework FrontendFramework) (FrontendCapabilities, bool)
 }
 
 // GetBackendFrameworks returns available backend frameworks
@@ -60,4 +100,145 @@ func GetFrontendFrameworks() []FrontendFramework {
 // GetProjectTypes returns available project types
 func GetProjectTypes() []ProjectType {
 	return []ProjectType{WebProject, APIProject}
-}
\ No newline at end of file
+}
+
+// DefaultProjectConfig returns the configuration used when an option is not
+// supplied and prompting is disabled
+func DefaultProjectConfig() *ProjectConfig {
+	return &ProjectConfig{
+		Path:             ".",
+		Type:             WebProject,
+		BackendFramework: Fiber,
+		Frontend: &FrontendConfig{
+			Framework:   NextJS,
+			TypeScript:  true,
+			TailwindCSS: true,
+			ESLint:      true,
+		},
+	}
+}
+
+// Supports reports whether the option behind field can be enabled
+func (c FrontendCapabilities) Supports(field ConfigField) bool {
+	switch field {
+	case FieldTypeScript:
+		return c.TypeScript
+	case FieldTailwindCSS:
+		return c.TailwindCSS
+	case FieldESLint:
+		return c.ESLint
+	case FieldPackageManager:
+		return len(c.PackageManagers) > 1
+	}
+	return true
+}
+
+// Restrict adjusts frontend to the capabilities: unsupported options are
+// turned off and the package manager falls back to the preferred one. Both
+// are recorded in set so they are not asked for. An error is returned when
+// set says an unsupported option was explicitly requested.
+func (c FrontendCapabilities) Restrict(frontend *FrontendConfig, set FieldSet) error {
+	options := []struct {
+		field ConfigField
+		value *bool
+		name  string
+	}{
+		{FieldTypeScript, &frontend.TypeScript, "TypeScript"},
+		{FieldTailwindCSS, &frontend.TailwindCSS, "Tailwind CSS"},
+		{FieldESLint, &frontend.ESLint, "ESLint"},
+	}
+	for _, option := range options {
+		if c.Supports(option.field) {
+			continue
+		}
+		if set[option.field] && *option.value {
+			return configErrorf("%s does not support %s", frontend.Framework, option.name)
+		}
+		*option.value = false
+		set[option.field] = true
+	}
+
+	if frontend.PackageManager != "" && !slices.Contains(c.PackageManagers, frontend.PackageManager) {
+		if set[FieldPackageManager] {
+			return configErrorf("%s does not support the %s package manager (supported: %s)", frontend.Framework, frontend.PackageManager, joinPackageManagers(c.PackageManagers))
+		}
+		frontend.PackageManager = ""
+	}
+	if frontend.PackageManager == "" && len(c.PackageManagers) > 0 {
+		frontend.PackageManager = c.PackageManagers[0]
+	}
+	if !c.Supports(FieldPackageManager) {
+		set[FieldPackageManager] = true
+	}
+
+	return nil
+}
+
+// ParsePackageManager returns the package manager matching s, ignoring case
+func ParsePackageManager(s string) (PackageManager, error) {
+	for _, pm := range GetPackageManagers() {
+		if normalizeName(string(pm)) == normalizeName(s) {
+			return pm, nil
+		}
+	}
+	return "", configErrorf("unknown package manager %q (available: %s)", s, joinPackageManagers(GetPackageManagers()))
+}
+
+// GetPackageManagers returns all known package managers
+func GetPackageManagers() []PackageManager {
+	return []PackageManager{Bun, Npm, Pnpm, Yarn}
+}
+
+// joinPackageManagers formats package managers for error messages
+func joinPackageManagers(pms []PackageManager) string {
+	names := make([]string, len(pms))
+	for i, pm := range pms {
+		names[i] = string(pm)
+	}
+	return strings.Join(names, ", ")
+}
+
+// ParseProjectType returns the project type matching s, ignoring case
+func ParseProjectType(s string) (ProjectType, error) {
+	for _, pt := range GetProjectTypes() {
+		if normalizeName(string(pt)) == normalizeName(s) {
+			return pt, nil
+		}
+	}
+	return "", configErrorf("unknown project type %q", s)
+}
+
+// ParseBackendFramework returns the backend framework matching s, ignoring case
+func ParseBackendFramework(s string) (BackendFramework, error) {
+	for _, fw := range GetBackendFrameworks() {
+		if normalizeName(string(fw)) == normalizeName(s) {
+			return fw, nil
+		}
+	}
+	return "", &UnknownFrameworkError{Kind: "backend", Name: s}
+}
+
+// ParseFrontendFramework returns the frontend framework matching s, ignoring
+// case and punctuation so that "nextjs", "next" and "Next.js" are equivalent
+func ParseFrontendFramework(s string) (FrontendFramework, error) {
+	name := normalizeName(s)
+	for _, fw := range GetFrontendFrameworks() {
+		normalized := normalizeName(string(fw))
+		if normalized == name || strings.TrimSuffix(normalized, "js") == name {
+			return fw, nil
+		}
+	}
+	return "", &UnknownFrameworkError{Kind: "frontend", Name: s}
+}
+
+// SameName reports whether a and b name the same framework, ignoring case and
+// the punctuation ParseBackendFramework and ParseFrontendFramework ignore
+func SameName(a, b string) bool {
+	return normalizeName(a) == normalizeName(b)
+}
+
+// normalizeName lowercases s and strips characters that are commonly omitted
+// when framework names are typed on the command line
+func normalizeName(s string) string {
+	return strings.NewReplacer(".", "", "-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(s)))
+}
@@ -0,0 +1,181 @@
+package userconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/verse91/fsgo-dev-kit/internal/spec"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+// DirEnv overrides the location of the fsgo configuration directory
+const DirEnv = "FSGO_CONFIG_DIR"
+
+// TemplateDirEnv names a directory of template overrides, used instead of
+// the templates directory of the configuration directory
+const TemplateDirEnv = "FSGO_TEMPLATE_DIR"
+
+// CacheDirEnv overrides the location of the fsgo cache directory, which
+// holds the clones of template packs
+const CacheDirEnv = "FSGO_CACHE_DIR"
+
+// presetNamePattern restricts preset names to characters safe in file names
+var presetNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Dir returns the fsgo configuration directory under the user config dir
+func Dir() (string, error) {
+	if dir := os.Getenv(DirEnv); dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("error locating user config directory: %v", err)
+	}
+	return filepath.Join(base, "fsgo"), nil
+}
+
+// CacheDir returns the fsgo cache directory under the user cache dir
+func CacheDir() (string, error) {
+	if dir := os.Getenv(CacheDirEnv); dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("error locating user cache directory: %v", err)
+	}
+	return filepath.Join(base, "fsgo"), nil
+}
+
+// PacksPath returns the directory template packs from git are cloned into
+func PacksPath() (string, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "packs"), nil
+}
+
+// DefaultsPath returns the path of the global defaults file
+func DefaultsPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "defaults.yaml"), nil
+}
+
+// TemplatesPath returns the directory of the user's template overrides
+func TemplatesPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "templates"), nil
+}
+
+// PresetPath returns the file a named preset is stored in
+func PresetPath(name string) (string, error) {
+	if !presetNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid preset name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "presets", name+".yaml"), nil
+}
+
+// LoadDefaults returns the built-in defaults overridden by the global defaults
+// file, if one exists. Name, path and module path in the defaults file are
+// ignored.
+func LoadDefaults(registry types.FrameworkRegistry) (*types.ProjectConfig, error) {
+	defaults := types.DefaultProjectConfig()
+
+	path, err := DefaultsPath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return defaults, nil
+	}
+
+	config, set, err := spec.Load(path, registry)
+	if err != nil {
+		return nil, err
+	}
+	delete(set, types.FieldPath)
+	delete(set, types.FieldModulePath)
+	types.FieldSet{}.Merge(defaults, config, set)
+
+	return defaults, nil
+}
+
+// SavePreset stores config under name, without its project name, path and
+// module path
+func SavePreset(name string, config *types.ProjectConfig) error {
+	path, err := PresetPath(name)
+	if err != nil {
+		return err
+	}
+	preset := *config
+	preset.Name = ""
+	preset.Path = ""
+	preset.ModulePath = ""
+	return spec.Save(path, &preset)
+}
+
+// LoadPreset reads a named preset and validates it against the registry
+func LoadPreset(name string, registry types.FrameworkRegistry) (*types.ProjectConfig, types.FieldSet, error) {
+	path, err := PresetPath(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("preset %q does not exist", name)
+	}
+	return spec.Load(path, registry)
+}
+
+// DeletePreset removes a named preset
+func DeletePreset(name string) error {
+	path, err := PresetPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("preset %q does not exist", name)
+		}
+		return err
+	}
+	return nil
+}
+
+// ListPresets returns the names of all saved presets in alphabetical order
+func ListPresets() ([]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "presets"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), ".yaml"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
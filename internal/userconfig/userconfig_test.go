@@ -0,0 +1,154 @@
+package userconfig_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/verse91/fsgo-dev-kit/internal/generator"
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/internal/userconfig"
+)
+
+// configDir points the fsgo configuration directory at a temporary directory
+// through XDG_CONFIG_HOME and returns it
+func configDir(t *testing.T) string {
+	t.Helper()
+	base := t.TempDir()
+	t.Setenv(userconfig.DirEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", base)
+	dir, err := userconfig.Dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestDir(t *testing.T) {
+	base := t.TempDir()
+	t.Setenv(userconfig.DirEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", base)
+	if dir, err := userconfig.Dir(); err != nil || dir != filepath.Join(base, "fsgo") {
+		t.Errorf("Dir() = %q, %v, want %q", dir, err, filepath.Join(base, "fsgo"))
+	}
+
+	override := t.TempDir()
+	t.Setenv(userconfig.DirEnv, override)
+	if dir, err := userconfig.Dir(); err != nil || dir != override {
+		t.Errorf("Dir() with %s = %q, %v, want %q", userconfig.DirEnv, dir, err, override)
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	dir := configDir(t)
+	registry := generator.NewGeneratorRegistry()
+
+	defaults, err := userconfig.LoadDefaults(registry)
+	if err != nil {
+		t.Fatalf("LoadDefaults without a file: %v", err)
+	}
+	if !reflect.DeepEqual(defaults, types.DefaultProjectConfig()) {
+		t.Errorf("defaults without a file = %+v, want the built-in defaults", defaults)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "defaults.yaml"), []byte(`name: shop
+path: apps/shop
+module-path: github.com/acme/shop/server
+backend: gin
+frontend:
+  framework: next
+  typescript: false
+  package-manager: pnpm
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaults, err = userconfig.LoadDefaults(registry)
+	if err != nil {
+		t.Fatalf("LoadDefaults: %v", err)
+	}
+	want := types.DefaultProjectConfig()
+	want.BackendFramework = types.Gin
+	want.Frontend.TypeScript = false
+	want.Frontend.PackageManager = types.Pnpm
+	if !reflect.DeepEqual(defaults, want) {
+		t.Errorf("defaults = %+v, frontend %+v\nwant %+v, frontend %+v", defaults, defaults.Frontend, want, want.Frontend)
+	}
+}
+
+func TestLoadDefaultsInvalid(t *testing.T) {
+	dir := configDir(t)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "defaults.yaml"), []byte("backend: rails\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := userconfig.LoadDefaults(generator.NewGeneratorRegistry())
+	if err == nil || !strings.Contains(err.Error(), "defaults.yaml:1") {
+		t.Errorf("LoadDefaults error = %v, want one at defaults.yaml:1", err)
+	}
+}
+
+func TestPresets(t *testing.T) {
+	configDir(t)
+	registry := generator.NewGeneratorRegistry()
+
+	config := &types.ProjectConfig{
+		Name:             "shop",
+		Path:             "apps/shop",
+		Type:             types.WebProject,
+		BackendFramework: types.Echo,
+		ModulePath:       "github.com/acme/shop/server",
+		Frontend:         &types.FrontendConfig{Framework: types.Svelte, PackageManager: types.Npm},
+	}
+	for _, name := range []string{"web", "api.v2"} {
+		if err := userconfig.SavePreset(name, config); err != nil {
+			t.Fatalf("SavePreset(%s): %v", name, err)
+		}
+	}
+
+	preset, set, err := userconfig.LoadPreset("web", registry)
+	if err != nil {
+		t.Fatalf("LoadPreset: %v", err)
+	}
+	if preset.Name != "" || preset.Path != "" || preset.ModulePath != "" {
+		t.Errorf("preset kept name %q, path %q and module path %q", preset.Name, preset.Path, preset.ModulePath)
+	}
+	if set[types.FieldPath] || set[types.FieldModulePath] {
+		t.Errorf("preset supplies the path or module path: %v", set)
+	}
+	if preset.BackendFramework != types.Echo || preset.Frontend == nil || preset.Frontend.Framework != types.Svelte {
+		t.Errorf("preset = %+v, frontend %+v", preset, preset.Frontend)
+	}
+	if config.Name != "shop" || config.ModulePath == "" {
+		t.Error("SavePreset modified the configuration it saved")
+	}
+
+	names, err := userconfig.ListPresets()
+	if err != nil || !slices.Equal(names, []string{"api.v2", "web"}) {
+		t.Errorf("ListPresets() = %q, %v", names, err)
+	}
+	if err := userconfig.DeletePreset("web"); err != nil {
+		t.Fatalf("DeletePreset: %v", err)
+	}
+	if _, _, err := userconfig.LoadPreset("web", registry); err == nil {
+		t.Error("deleted preset still loads")
+	}
+	if err := userconfig.DeletePreset("web"); err == nil {
+		t.Error("deleting a missing preset succeeded")
+	}
+
+	for _, name := range []string{"", ".hidden", "../escape", "a/b", "my preset"} {
+		if err := userconfig.SavePreset(name, config); err == nil {
+			t.Errorf("SavePreset(%q) succeeded", name)
+		}
+	}
+}
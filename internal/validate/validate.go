@@ -0,0 +1,190 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+)
+
+// maxNpmNameLength is the longest package name npm accepts
+const maxNpmNameLength = 214
+
+// NameError explains which downstream tool would reject a project name
+type NameError struct {
+	Name       string
+	Tool       string
+	Reason     string
+	Suggestion string
+}
+
+func (e *NameError) Error() string {
+	msg := fmt.Sprintf("invalid project name %q: %s would reject it because %s", e.Name, e.Tool, e.Reason)
+	if e.Suggestion != "" && e.Suggestion != e.Name {
+		msg += fmt.Sprintf(" (try %q)", e.Suggestion)
+	}
+	return msg
+}
+
+// Unwrap classifies the error as types.ErrInvalidConfig
+func (e *NameError) Unwrap() error {
+	return types.ErrInvalidConfig
+}
+
+// DirNotEmptyError is returned when the target directory already has files
+type DirNotEmptyError struct {
+	Path string
+}
+
+func (e *DirNotEmptyError) Error() string {
+	return fmt.Sprintf("directory %s is not empty", e.Path)
+}
+
+// Project checks the project name and path together
+func Project(name, path string) error {
+	if err := ProjectPath(path); err != nil {
+		return err
+	}
+	return ProjectName(name)
+}
+
+// ProjectPath checks that path is a usable, relative project location
+func ProjectPath(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return &types.ConfigError{Message: "project path must not be empty"}
+	}
+	if filepath.IsAbs(path) {
+		return &types.ConfigError{Message: fmt.Sprintf("project path %q must be relative to the current directory", path)}
+	}
+	for _, segment := range strings.Split(filepath.ToSlash(filepath.Clean(path)), "/") {
+		if segment == ".." {
+			return &types.ConfigError{Message: fmt.Sprintf("project path %q must not leave the current directory", path)}
+		}
+	}
+	return nil
+}
+
+// ProjectName checks that name is accepted both as an npm package name, as
+// used by npm and bun create for the frontend, and as a Go module path
+// element, as used by go mod init for the backend
+func ProjectName(name string) error {
+	if err := npmName(name); err != nil {
+		return err
+	}
+	return goModuleElement(name)
+}
+
+// npmName applies the rules of npm's validate-npm-package-name
+func npmName(name string) error {
+	reject := func(reason string) error {
+		return &NameError{Name: name, Tool: "npm and bun create", Reason: reason, Suggestion: NormalizeName(name)}
+	}
+
+	switch {
+	case name == "":
+		return reject("it is empty")
+	case len(name) > maxNpmNameLength:
+		return reject(fmt.Sprintf("it is longer than %d characters", maxNpmNameLength))
+	case strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_"):
+		return reject("it starts with '.' or '_'")
+	case strings.ContainsAny(name, " \t"):
+		return reject("it contains spaces")
+	case strings.ToLower(name) != name:
+		return reject("it contains uppercase letters")
+	case name == "node_modules" || name == "favicon.ico":
+		return reject("it is a reserved name")
+	}
+	for _, r := range name {
+		if !isLowerAlphaNum(r) && !strings.ContainsRune("-._~", r) {
+			return reject(fmt.Sprintf("it contains the character %q, which is not URL-safe", r))
+		}
+	}
+	return nil
+}
+
+// goModuleElement applies the rules Go uses for module path elements
+func goModuleElement(name string) error {
+	reject := func(reason string) error {
+		return &NameError{Name: name, Tool: "go mod init", Reason: reason, Suggestion: NormalizeName(name)}
+	}
+
+	if strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") {
+		return reject("a module path element can't begin or end with '.'")
+	}
+	for _, r := range name {
+		if !isLowerAlphaNum(r) && !(r >= 'A' && r <= 'Z') && !strings.ContainsRune("-._~", r) {
+			return reject(fmt.Sprintf("a module path element can't contain %q", r))
+		}
+	}
+	return nil
+}
+
+// ModulePath checks that path is accepted by go mod init as a module path:
+// slash-separated elements following the rules of goModuleElement, without
+// empty, "." or ".." elements
+func ModulePath(path string) error {
+	reject := func(reason string) error {
+		return &types.ConfigError{Message: fmt.Sprintf("invalid module path %q: %s", path, reason)}
+	}
+
+	if strings.TrimSpace(path) == "" {
+		return reject("it is empty")
+	}
+	if strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") {
+		return reject("it can't begin or end with '/'")
+	}
+	for _, element := range strings.Split(path, "/") {
+		if element == "" {
+			return reject("it can't contain '//'")
+		}
+		if err := goModuleElement(element); err != nil {
+			return reject(err.(*NameError).Reason)
+		}
+	}
+	return nil
+}
+
+// NormalizeName converts name into the closest name accepted by ProjectName
+func NormalizeName(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if isLowerAlphaNum(r) || r == '.' || r == '_' || r == '~' {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+
+	normalized := strings.TrimLeft(strings.TrimRight(b.String(), "-."), "._-")
+	if len(normalized) > maxNpmNameLength {
+		normalized = strings.TrimRight(normalized[:maxNpmNameLength], "-.")
+	}
+	return normalized
+}
+
+// TargetDir returns a *DirNotEmptyError when path exists and has entries
+func TargetDir(path string) error {
+	entries, err := os.ReadDir(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return &DirNotEmptyError{Path: path}
+	}
+	return nil
+}
+
+// isLowerAlphaNum reports whether r is an ASCII lowercase letter or digit
+func isLowerAlphaNum(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')
+}
@@ -0,0 +1,169 @@
+package validate_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/verse91/fsgo-dev-kit/internal/types"
+	"github.com/verse91/fsgo-dev-kit/internal/validate"
+)
+
+func TestProjectName(t *testing.T) {
+	tests := []struct {
+		name string
+		// tool is the tool that rejects name, empty when it is valid
+		tool       string
+		reason     string
+		suggestion string
+	}{
+		{"shop", "", "", ""},
+		{"my-shop.v2_x~", "", "", ""},
+		{"", "npm and bun create", "it is empty", ""},
+		{strings.Repeat("a", 215), "npm and bun create", "longer than 214", strings.Repeat("a", 214)},
+		{".shop", "npm and bun create", "starts with '.' or '_'", "shop"},
+		{"_shop", "npm and bun create", "starts with '.' or '_'", "shop"},
+		{"..", "npm and bun create", "starts with '.' or '_'", ""},
+		{"my shop", "npm and bun create", "contains spaces", "my-shop"},
+		{"MyShop", "npm and bun create", "uppercase", "myshop"},
+		{"node_modules", "npm and bun create", "reserved", "node_modules"},
+		{"favicon.ico", "npm and bun create", "reserved", "favicon.ico"},
+		{"shop!", "npm and bun create", "not URL-safe", "shop"},
+		{"café", "npm and bun create", "not URL-safe", "caf"},
+		{"shop.", "go mod init", "begin or end with '.'", "shop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.ProjectName(tt.name)
+			if tt.tool == "" {
+				if err != nil {
+					t.Errorf("ProjectName(%q) = %v", tt.name, err)
+				}
+				return
+			}
+
+			var nameErr *validate.NameError
+			if !errors.As(err, &nameErr) {
+				t.Fatalf("ProjectName(%q) = %v, want a NameError", tt.name, err)
+			}
+			if nameErr.Tool != tt.tool || !strings.Contains(nameErr.Reason, tt.reason) {
+				t.Errorf("%s rejects because %s, want %s because of %q", nameErr.Tool, nameErr.Reason, tt.tool, tt.reason)
+			}
+			if nameErr.Suggestion != tt.suggestion {
+				t.Errorf("suggestion = %q, want %q", nameErr.Suggestion, tt.suggestion)
+			}
+			if !errors.Is(err, types.ErrInvalidConfig) {
+				t.Error("NameError is not an ErrInvalidConfig")
+			}
+		})
+	}
+}
+
+func TestModulePath(t *testing.T) {
+	for path, valid := range map[string]bool{
+		"shop":                        true,
+		"shop/server":                 true,
+		"github.com/acme/Shop/server": true,
+		"example.com/a~b_c-d.e/v2":    true,
+		"":                            false,
+		" ":                           false,
+		"/shop":                       false,
+		"shop/":                       false,
+		"bad//path":                   false,
+		"shop/./server":               false,
+		"shop/../server":              false,
+		"shop/.server":                false,
+		"shop/server.":                false,
+		"my shop/server":              false,
+		"shop/ser@ver":                false,
+	} {
+		err := validate.ModulePath(path)
+		if valid && err != nil {
+			t.Errorf("ModulePath(%q) = %v", path, err)
+		}
+		if !valid && !errors.Is(err, types.ErrInvalidConfig) {
+			t.Errorf("ModulePath(%q) = %v, want ErrInvalidConfig", path, err)
+		}
+	}
+}
+
+func TestProjectPath(t *testing.T) {
+	for path, valid := range map[string]bool{
+		"shop":                              true,
+		".":                                 true,
+		"apps/shop":                         true,
+		"apps/../shop":                      true,
+		"":                                  false,
+		"  ":                                false,
+		"..":                                false,
+		"../shop":                           false,
+		"apps/../../x":                      false,
+		string(filepath.Separator) + "shop": false,
+	} {
+		err := validate.ProjectPath(path)
+		if valid && err != nil {
+			t.Errorf("ProjectPath(%q) = %v", path, err)
+		}
+		if !valid && !errors.Is(err, types.ErrInvalidConfig) {
+			t.Errorf("ProjectPath(%q) = %v, want ErrInvalidConfig", path, err)
+		}
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	for name, want := range map[string]string{
+		"shop":          "shop",
+		"My Shop":       "my-shop",
+		"  My   Shop  ": "my-shop",
+		"my_shop.v2":    "my_shop.v2",
+		"--shop--":      "shop",
+		"._shop":        "shop",
+		"shop.":         "shop",
+		"Shop & Co!":    "shop-co",
+		"..":            "",
+		"日本":            "",
+	} {
+		got := validate.NormalizeName(name)
+		if got != want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", name, got, want)
+		}
+		if got != "" {
+			if err := validate.ProjectName(got); err != nil {
+				t.Errorf("normalized name %q is rejected: %v", got, err)
+			}
+		}
+	}
+}
+
+func TestTargetDir(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	full := filepath.Join(dir, "full")
+	for _, d := range []string{empty, full} {
+		if err := os.Mkdir(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(full, ".gitignore"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{empty, filepath.Join(dir, "missing")} {
+		if err := validate.TargetDir(path); err != nil {
+			t.Errorf("TargetDir(%s) = %v", path, err)
+		}
+	}
+
+	var notEmpty *validate.DirNotEmptyError
+	if err := validate.TargetDir(full); !errors.As(err, &notEmpty) || notEmpty.Path != full {
+		t.Errorf("TargetDir(%s) = %v, want a DirNotEmptyError", full, err)
+	}
+
+	file := filepath.Join(full, ".gitignore")
+	if err := validate.TargetDir(file); err == nil {
+		t.Errorf("TargetDir(%s) accepted a file", file)
+	}
+}
@@ -0,0 +1,93 @@
+package fsys
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+)
+
+// ErrReadOnly is returned when writing to a read-only filesystem
+var ErrReadOnly = errors.New("read-only filesystem")
+
+// FS is the filesystem generators write to. Names are slash-separated paths
+// relative to the root of the filesystem and must satisfy fs.ValidPath, so
+// nothing outside the root can be reached.
+type FS interface {
+	// MkdirAll creates a directory along with any missing parents
+	MkdirAll(name string, perm fs.FileMode) error
+	// WriteFile writes data to a file, creating or truncating it. The parent
+	// directory must exist.
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	// ReadFile returns the contents of a file
+	ReadFile(name string) ([]byte, error)
+	// Stat returns information about a file or directory
+	Stat(name string) (fs.FileInfo, error)
+	// Dir returns the directory on disk the filesystem is rooted at, or ""
+	// when it is not backed by one
+	Dir() string
+}
+
+// Sub returns the filesystem rooted at dir within fsys
+func Sub(fsys FS, dir string) (FS, error) {
+	if !fs.ValidPath(dir) {
+		return nil, &fs.PathError{Op: "sub", Path: dir, Err: fs.ErrInvalid}
+	}
+	if dir == "." {
+		return fsys, nil
+	}
+	return &subFS{parent: fsys, dir: dir}, nil
+}
+
+// subFS is a filesystem rooted at a directory of its parent
+type subFS struct {
+	parent FS
+	dir    string
+}
+
+// join resolves name against the sub directory
+func (s *subFS) join(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return path.Join(s.dir, name), nil
+}
+
+func (s *subFS) MkdirAll(name string, perm fs.FileMode) error {
+	full, err := s.join("mkdir", name)
+	if err != nil {
+		return err
+	}
+	return s.parent.MkdirAll(full, perm)
+}
+
+func (s *subFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	full, err := s.join("write", name)
+	if err != nil {
+		return err
+	}
+	return s.parent.WriteFile(full, data, perm)
+}
+
+func (s *subFS) ReadFile(name string) ([]byte, error) {
+	full, err := s.join("read", name)
+	if err != nil {
+		return nil, err
+	}
+	return s.parent.ReadFile(full)
+}
+
+func (s *subFS) Stat(name string) (fs.FileInfo, error) {
+	full, err := s.join("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return s.parent.Stat(full)
+}
+
+func (s *subFS) Dir() string {
+	parent := s.parent.Dir()
+	if parent == "" {
+		return ""
+	}
+	return joinOS(parent, s.dir)
+}
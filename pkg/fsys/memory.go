@@ -0,0 +1,133 @@
+package fsys
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryFS is an in-memory filesystem, useful for tests and for previewing
+// what a generator writes. It is safe for concurrent use.
+type MemoryFS struct {
+	mu      sync.RWMutex
+	entries map[string]*memEntry
+}
+
+// memEntry is a file or directory of a MemoryFS
+type memEntry struct {
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// NewMemory returns an empty in-memory filesystem
+func NewMemory() *MemoryFS {
+	return &MemoryFS{
+		entries: map[string]*memEntry{
+			".": {mode: fs.ModeDir | 0o755, modTime: time.Now()},
+		},
+	}
+}
+
+func (m *MemoryFS) MkdirAll(name string, perm fs.FileMode) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Create parents first so every directory on the way is recorded
+	var dirs []string
+	for dir := name; dir != "."; dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if entry, exists := m.entries[dirs[i]]; exists {
+			if !entry.mode.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: dirs[i], Err: fs.ErrExist}
+			}
+			continue
+		}
+		m.entries[dirs[i]] = &memEntry{mode: fs.ModeDir | perm.Perm(), modTime: time.Now()}
+	}
+	return nil
+}
+
+func (m *MemoryFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if parent, exists := m.entries[path.Dir(name)]; !exists || !parent.mode.IsDir() {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrNotExist}
+	}
+	if entry, exists := m.entries[name]; exists && entry.mode.IsDir() {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrExist}
+	}
+	m.entries[name] = &memEntry{data: append([]byte(nil), data...), mode: perm.Perm(), modTime: time.Now()}
+	return nil
+}
+
+func (m *MemoryFS) ReadFile(name string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entry, exists := m.entries[name]
+	if !exists {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	if entry.mode.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	return append([]byte(nil), entry.data...), nil
+}
+
+func (m *MemoryFS) Stat(name string) (fs.FileInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entry, exists := m.entries[name]
+	if !exists {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return &memFileInfo{name: path.Base(name), entry: entry}, nil
+}
+
+// Dir returns "" because a MemoryFS is not backed by a directory
+func (m *MemoryFS) Dir() string {
+	return ""
+}
+
+// Paths returns the paths of all files and directories, sorted, without the root
+func (m *MemoryFS) Paths() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	paths := make([]string, 0, len(m.entries))
+	for name := range m.entries {
+		if name != "." {
+			paths = append(paths, name)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// memFileInfo implements fs.FileInfo for a MemoryFS entry
+type memFileInfo struct {
+	name  string
+	entry *memEntry
+}
+
+func (i *memFileInfo) Name() string       { return i.name }
+func (i *memFileInfo) Size() int64        { return int64(len(i.entry.data)) }
+func (i *memFileInfo) Mode() fs.FileMode  { return i.entry.mode }
+func (i *memFileInfo) ModTime() time.Time { return i.entry.modTime }
+func (i *memFileInfo) IsDir() bool        { return i.entry.mode.IsDir() }
+func (i *memFileInfo) Sys() any           { return nil }
@@ -0,0 +1,71 @@
+package fsys
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// osFS is a filesystem rooted at a directory on disk
+type osFS struct {
+	root string
+}
+
+// NewOS returns a filesystem rooted at dir. Relative directories are resolved
+// against the current working directory once, when the filesystem is created.
+func NewOS(dir string) (FS, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &osFS{root: root}, nil
+}
+
+// path converts name to a path on disk
+func (o *osFS) path(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return joinOS(o.root, name), nil
+}
+
+func (o *osFS) MkdirAll(name string, perm fs.FileMode) error {
+	full, err := o.path("mkdir", name)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(full, perm)
+}
+
+func (o *osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	full, err := o.path("write", name)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(full, data, perm)
+}
+
+func (o *osFS) ReadFile(name string) ([]byte, error) {
+	full, err := o.path("read", name)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(full)
+}
+
+func (o *osFS) Stat(name string) (fs.FileInfo, error) {
+	full, err := o.path("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return os.Stat(full)
+}
+
+func (o *osFS) Dir() string {
+	return o.root
+}
+
+// joinOS joins a directory on disk and a slash-separated relative name
+func joinOS(dir, name string) string {
+	return filepath.Join(dir, filepath.FromSlash(name))
+}
@@ -0,0 +1,36 @@
+package fsys
+
+import "io/fs"
+
+// readOnlyFS rejects every write to the filesystem it wraps
+type readOnlyFS struct {
+	fsys FS
+}
+
+// NewReadOnly returns a view of fsys that can be read but not written, e.g.
+// to inspect an existing project without any risk of modifying it
+func NewReadOnly(fsys FS) FS {
+	return &readOnlyFS{fsys: fsys}
+}
+
+func (r *readOnlyFS) MkdirAll(name string, perm fs.FileMode) error {
+	return &fs.PathError{Op: "mkdir", Path: name, Err: ErrReadOnly}
+}
+
+func (r *readOnlyFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return &fs.PathError{Op: "write", Path: name, Err: ErrReadOnly}
+}
+
+func (r *readOnlyFS) ReadFile(name string) ([]byte, error) {
+	return r.fsys.ReadFile(name)
+}
+
+func (r *readOnlyFS) Stat(name string) (fs.FileInfo, error) {
+	return r.fsys.Stat(name)
+}
+
+// Dir returns "" so external commands, which could write anywhere, are not
+// run against a read-only filesystem
+func (r *readOnlyFS) Dir() string {
+	return ""
+}
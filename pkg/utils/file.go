@@ -2,8 +2,9 @@ package utils
 
 import (
 	"os"
-	"os/exec"
 	"path/filepath"
+
+	"golang.org/x/term"
 )
 
 // CreateFile creates a file with the given content at the specified path.
@@ -32,33 +33,11 @@ func CreateDirectory(path string, perm os.FileMode) error {
 	return os.MkdirAll(path, perm)
 }
 
-// RunCommand executes a shell command with stdout and stderr connected
-func RunCommand(name string, args ...string) error {
-	cmd := exec.Command(name, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
-}
-
-// RunCommandInDir executes a shell command in a specific directory
-func RunCommandInDir(dir, name string, args ...string) error {
-	cmd := exec.Command(name, args...)
-	cmd.Dir = dir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
-}
-
 // GetCurrentDir returns the current working directory
 func GetCurrentDir() (string, error) {
 	return os.Getwd()
 }
 
-// ChangeDir changes the current working directory
-func ChangeDir(dir string) error {
-	return os.Chdir(dir)
-}
-
 // GetProjectName returns the base name of the current directory as project name
 func GetProjectName() (string, error) {
 	currentDir, err := GetCurrentDir()
@@ -66,4 +45,20 @@ func GetProjectName() (string, error) {
 		return "", err
 	}
 	return filepath.Base(currentDir), nil
-}
\ No newline at end of file
+}
+
+// ProjectNameFromPath returns the project name for a project created at path,
+// which is the last element of the path. The current directory's base name is
+// used when path is ".".
+func ProjectNameFromPath(path string) (string, error) {
+	path = filepath.Clean(path)
+	if path == "." {
+		return GetProjectName()
+	}
+	return filepath.Base(path), nil
+}
+
+// IsTerminal reports whether f is connected to a terminal
+func IsTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
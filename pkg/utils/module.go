@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// serverDir is the directory of the generated project holding the Go module
+const serverDir = "server"
+
+// gitTimeout bounds the git commands run to find the default module path
+const gitTimeout = 2 * time.Second
+
+// DefaultModulePath returns the Go module path suggested for the server of a
+// project created at projectPath. Inside a git repository with an origin
+// remote it follows the remote, e.g. github.com/acme/shop/my-app/server for
+// the project my-app in a clone of git@github.com:acme/shop.git. Otherwise it
+// is ModulePathFromName(name).
+func DefaultModulePath(projectPath, name string) string {
+	if modulePath, ok := modulePathFromGit(projectPath); ok {
+		return modulePath
+	}
+	return ModulePathFromName(name)
+}
+
+// ModulePathFromName returns the module path of the server of the project
+// name when there is no git remote to derive it from, e.g. my-app/server
+func ModulePathFromName(name string) string {
+	if name == "" {
+		return serverDir
+	}
+	return name + "/" + serverDir
+}
+
+// ModulePathFromRemote converts a git remote URL such as
+// https://github.com/acme/shop.git, ssh://git@github.com/acme/shop or
+// git@github.com:acme/shop.git into the module path github.com/acme/shop. It
+// reports false for remotes without a host, such as local paths.
+func ModulePathFromRemote(remote string) (string, bool) {
+	remote = strings.TrimSpace(remote)
+	var host, repo string
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		switch u.Scheme {
+		case "https", "http", "ssh", "git":
+			host, repo = u.Hostname(), u.Path
+		default:
+			return "", false
+		}
+	} else if at := strings.Index(remote, "@"); at >= 0 && strings.Contains(remote[at:], ":") {
+		// scp-like syntax: [user@]host:owner/repo.git
+		host, repo, _ = strings.Cut(remote[at+1:], ":")
+	} else {
+		return "", false
+	}
+
+	repo = strings.TrimSuffix(strings.Trim(repo, "/"), ".git")
+	if host == "" || repo == "" {
+		return "", false
+	}
+	return host + "/" + repo, true
+}
+
+// modulePathFromGit derives the module path of the server of the project at
+// projectPath from the origin remote of the git repository it is created in
+func modulePathFromGit(projectPath string) (string, bool) {
+	dir, rest, err := existingParent(projectPath)
+	if err != nil {
+		return "", false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+	git := func(args ...string) (string, bool) {
+		out, err := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...).Output()
+		return strings.TrimSpace(string(out)), err == nil
+	}
+
+	remote, ok := git("remote", "get-url", "origin")
+	if !ok {
+		return "", false
+	}
+	modulePath, ok := ModulePathFromRemote(remote)
+	if !ok {
+		return "", false
+	}
+	toplevel, ok := git("rev-parse", "--show-toplevel")
+	if !ok {
+		return "", false
+	}
+	if toplevel, err = filepath.EvalSymlinks(toplevel); err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(toplevel, filepath.Join(dir, rest))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return path.Join(modulePath, filepath.ToSlash(rel), serverDir), true
+}
+
+// existingParent splits the absolute path of p into its deepest existing
+// directory, with symbolic links resolved, and the rest of the path
+func existingParent(p string) (string, string, error) {
+	dir, err := filepath.Abs(p)
+	if err != nil {
+		return "", "", err
+	}
+	rest := ""
+	for {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			resolved, err := filepath.EvalSymlinks(dir)
+			return resolved, rest, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", os.ErrNotExist
+		}
+		rest = filepath.Join(filepath.Base(dir), rest)
+		dir = parent
+	}
+}